@@ -0,0 +1,35 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import "context"
+
+// JobsAPI is the set of job operations of the cron-job.org API. It is implemented by
+// *Client and by clienttest.MockJobsAPI, so that code using the API can be tested
+// without an HTTP server.
+type JobsAPI interface {
+	// GetJobs lists all jobs and reports whether the list is incomplete.
+	GetJobs(ctx context.Context) (jobs []Job, incomplete bool, err error)
+	// GetJob returns a job as included in the job list.
+	GetJob(ctx context.Context, jobID string) (*Job, error)
+	// GetJobDetails returns a job including its auth, notification and extended data.
+	GetJobDetails(ctx context.Context, jobID string) (*DetailedJob, error)
+	// ListedJob returns a job from the cached job list, if the cache is enabled and
+	// the job is in it.
+	ListedJob(ctx context.Context, jobID string) (*Job, bool, error)
+	// JobCacheEnabled reports whether job reads are served from a cached job list.
+	JobCacheEnabled() bool
+	// CreateJob creates a job and returns its ID.
+	CreateJob(ctx context.Context, job JobCreate) (int, error)
+	// UpdateJob changes the fields of a job that are set in patch.
+	UpdateJob(ctx context.Context, jobID string, patch JobPatch) error
+	// DeleteJob deletes a job.
+	DeleteJob(ctx context.Context, jobID string) error
+	// GetJobHistory returns the recent executions of a job and its predicted next executions.
+	GetJobHistory(ctx context.Context, jobID string) ([]JobHistory, []int, error)
+	// GetJobHistoryItem returns a single execution of a job, including the response.
+	GetJobHistoryItem(ctx context.Context, jobID, identifier string) (*JobHistory, error)
+}
+
+var _ JobsAPI = (*Client)(nil)
@@ -7,53 +7,101 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"net/http/httptrace"
+	"net/url"
+	"sync/atomic"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/plain-insure/terraform-provider-cronjoborg/internal/redact"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
 )
 
+// DefaultIncompleteListRetries is the default number of times an incomplete job listing is repeated.
+const DefaultIncompleteListRetries = 2
+
 type Client struct {
 	BaseURL    string
 	APIKey     string
 	HTTPClient *http.Client
-}
+	UserAgent  string
+	Retry      RetryPolicy
 
-// APIError represents an error response from the API.
-type APIError struct {
-	StatusCode int
-	Message    string
-	Body       string
-}
+	// RateLimiter throttles requests client-side. A nil RateLimiter disables throttling.
+	RateLimiter *RateLimiter
+
+	// Quota enforces the request budget. A nil Quota disables request accounting.
+	Quota *QuotaTracker
+
+	// JobCache serves job reads from a single GET /jobs response. A nil JobCache
+	// disables the cache.
+	JobCache *JobListCache
 
-func (e *APIError) Error() string {
-	return fmt.Sprintf("API error %d: %s", e.StatusCode, e.Message)
+	// IncompleteListRetries is the number of times GetJobs repeats a listing that
+	// the API reports as incomplete.
+	IncompleteListRetries int
+
+	// redactor removes credentials from logged requests and responses.
+	redactor *redact.Redactor
+
+	// tracer creates a span for every API call.
+	tracer trace.Tracer
 }
 
-func NewClient(baseURL, apiKey string) *Client {
-	return &Client{
+// NewClient returns a client for the API at baseURL. Without options, requests time
+// out after DefaultTimeout, use the proxy from the environment and are retried
+// according to DefaultRetryPolicy.
+func NewClient(baseURL, apiKey string, opts ...Option) *Client {
+	o := clientOptions{
+		userAgent:      DefaultUserAgent,
+		tracerProvider: otel.GetTracerProvider(),
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	c := &Client{
 		BaseURL:    baseURL,
 		APIKey:     apiKey,
-		HTTPClient: http.DefaultClient,
+		HTTPClient: o.newHTTPClient(),
+		UserAgent:  o.userAgent,
+		Retry:      DefaultRetryPolicy(),
+
+		RateLimiter: NewRateLimiter(DefaultRateLimits()),
+
+		IncompleteListRetries: DefaultIncompleteListRetries,
+
+		redactor: redact.New(o.sensitiveHeaders...),
+		tracer:   o.tracerProvider.Tracer(TracerName),
+	}
+	if o.retry != nil {
+		c.Retry = *o.retry
 	}
+	return c
 }
 
 // Job represents a cron job from the API.
 type Job struct {
-	JobID           int         `json:"jobId"`
-	Enabled         bool        `json:"enabled"`
-	Title           string      `json:"title"`
-	SaveResponses   bool        `json:"saveResponses"`
-	URL             string      `json:"url"`
-	LastStatus      int         `json:"lastStatus"`
-	LastDuration    int         `json:"lastDuration"`
-	LastExecution   int         `json:"lastExecution"`
-	NextExecution   *int        `json:"nextExecution"` // Nullable
-	Type            int         `json:"type"`
-	RequestTimeout  int         `json:"requestTimeout"`
-	RedirectSuccess bool        `json:"redirectSuccess"`
-	FolderID        int         `json:"folderId"`
-	Schedule        JobSchedule `json:"schedule"`
-	RequestMethod   int         `json:"requestMethod"`
+	JobID           int           `json:"jobId"`
+	Enabled         bool          `json:"enabled"`
+	Title           string        `json:"title"`
+	SaveResponses   bool          `json:"saveResponses"`
+	URL             string        `json:"url"`
+	LastStatus      JobStatus     `json:"lastStatus"`
+	LastDuration    int           `json:"lastDuration"`
+	LastExecution   int           `json:"lastExecution"`
+	NextExecution   *int          `json:"nextExecution"` // Nullable
+	Type            JobType       `json:"type"`
+	RequestTimeout  int           `json:"requestTimeout"`
+	RedirectSuccess bool          `json:"redirectSuccess"`
+	FolderID        int           `json:"folderId"`
+	Schedule        JobSchedule   `json:"schedule"`
+	RequestMethod   RequestMethod `json:"requestMethod"`
 }
 
 // DetailedJob represents a cron job with detailed settings from the API.
@@ -146,7 +194,7 @@ type JobHistory struct {
 	Jitter      int                 `json:"jitter"`
 	URL         string              `json:"url"`
 	Duration    int                 `json:"duration"`
-	Status      int                 `json:"status"`
+	Status      JobStatus           `json:"status"`
 	StatusText  string              `json:"statusText"`
 	HttpStatus  int                 `json:"httpStatus"`
 	Headers     *string             `json:"headers"` // Nullable
@@ -176,44 +224,122 @@ type JobHistoryResponse struct {
 	Predictions []int        `json:"predictions"`
 }
 
+// JobHistoryItemResponse represents the API response for a single history item.
+type JobHistoryItemResponse struct {
+	JobHistoryDetails JobHistory `json:"jobHistoryDetails"`
+}
+
 // JobDetailsResponse represents the API response for job details.
 type JobDetailsResponse struct {
 	JobDetails DetailedJob `json:"jobDetails"`
 }
 
-// doRequest performs an HTTP request to the cron-job.org API.
-func (c *Client) doRequest(method, path string, body interface{}) (*http.Response, error) {
-	var bodyReader *bytes.Reader
+// doRequest performs an HTTP request to the cron-job.org API, retrying transient
+// failures according to the client's retry policy. Each call is traced as one span.
+func (c *Client) doRequest(ctx context.Context, method, path string, body interface{}) (resp *http.Response, err error) {
+	var payload []byte
 	if body != nil {
 		j, err := json.Marshal(body)
 		if err != nil {
-			return nil, fmt.Errorf("failed to marshal request body: %w", err)
+			return nil, markNotSent(fmt.Errorf("failed to marshal request body: %w", err), false)
+		}
+		payload = j
+	}
+
+	ctx, span := c.startSpan(ctx, method, path)
+	attempt := 0
+	var rateLimitWait time.Duration
+	defer func() {
+		endSpan(span, attempt, rateLimitWait, statusCode(resp, err), err)
+	}()
+
+	ctx = c.logContext(ctx)
+	class := endpointClass(method, path)
+	// sent records whether any attempt may have reached the API.
+	sent := false
+	for attempt = 1; ; attempt++ {
+		waited, err := c.RateLimiter.Wait(ctx, class)
+		rateLimitWait += waited
+		if err != nil {
+			return nil, markNotSent(fmt.Errorf("failed waiting for rate limiter: %w", err), sent)
+		}
+		if err := c.Quota.Acquire(ctx); err != nil {
+			return nil, markNotSent(err, sent)
+		}
+
+		resp, attemptSent, err := c.send(ctx, method, path, payload, body != nil, attempt)
+		sent = sent || attemptSent
+		if err == nil {
+			return resp, nil
+		}
+		err = markNotSent(err, sent)
+		if ctx.Err() != nil {
+			return nil, err
+		}
+
+		wait, retry := c.Retry.retryDelay(method, attempt, err)
+		if !retry {
+			return nil, err
+		}
+		tflog.SubsystemDebug(ctx, LogSubsystem, "Retrying API request", map[string]interface{}{
+			"method":  method,
+			"path":    path,
+			"attempt": attempt,
+			"wait_ms": wait.Milliseconds(),
+			"error":   err.Error(),
+		})
+		if sleepErr := sleepContext(ctx, wait); sleepErr != nil {
+			return nil, fmt.Errorf("%w (retry aborted: %w)", err, sleepErr)
 		}
-		bodyReader = bytes.NewReader(j)
-	} else {
-		bodyReader = bytes.NewReader([]byte{})
 	}
+}
+
+// statusCode returns the HTTP status of the last attempt of a request, or 0 if no
+// response was received.
+func statusCode(resp *http.Response, err error) int {
+	if resp != nil {
+		return resp.StatusCode
+	}
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode
+	}
+	return 0
+}
 
-	req, err := http.NewRequestWithContext(context.Background(), method, c.BaseURL+path, bodyReader)
+// send performs a single HTTP request attempt and converts error responses into *APIError.
+// It also reports whether the request may have reached the API.
+func (c *Client) send(ctx context.Context, method, path string, payload []byte, hasBody bool, attempt int) (*http.Response, bool, error) {
+	var trace requestTrace
+	req, err := http.NewRequestWithContext(httptrace.WithClientTrace(ctx, trace.clientTrace()), method, c.BaseURL+path, bytes.NewReader(payload))
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return nil, false, fmt.Errorf("failed to create request: %w", err)
 	}
 
 	req.Header.Set("Authorization", "Bearer "+c.APIKey)
-	if body != nil {
+	if c.UserAgent != "" {
+		req.Header.Set("User-Agent", c.UserAgent)
+	}
+	if hasBody {
 		req.Header.Set("Content-Type", "application/json")
 	}
 
+	c.logRequest(ctx, req, payload, attempt)
+	start := time.Now()
 	resp, err := c.HTTPClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to execute request: %w", err)
+		c.logResponse(ctx, req, attempt, start, nil, nil, err)
+		return nil, trace.sent(ctx), fmt.Errorf("failed to execute request: %w", err)
 	}
 
+	// Read the body here so that a connection dropped mid-response fails this attempt
+	// and is retried like any other transport error, instead of failing to decode later.
+	bodyBytes, readErr := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	c.logResponse(ctx, req, attempt, start, resp, bodyBytes, readErr)
+
 	// Check for API errors
 	if resp.StatusCode >= 400 {
-		defer resp.Body.Close()
-		bodyBytes, _ := io.ReadAll(resp.Body)
-
 		// Try to parse error message from response
 		var errorResp struct {
 			Error   string `json:"error"`
@@ -229,19 +355,72 @@ func (c *Client) doRequest(method, path string, body interface{}) (*http.Respons
 			}
 		}
 
-		return nil, &APIError{
+		return nil, true, &APIError{
+			Method:     method,
+			Path:       path,
 			StatusCode: resp.StatusCode,
 			Message:    errorMessage,
 			Body:       string(bodyBytes),
+			retryAfter: parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()),
 		}
 	}
 
-	return resp, nil
+	if readErr != nil {
+		return nil, true, fmt.Errorf("failed to read response body: %w", readErr)
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(bodyBytes))
+
+	return resp, true, nil
+}
+
+// requestTrace records whether a request attempt was written to a connection.
+type requestTrace struct {
+	connecting atomic.Bool
+	wrote      atomic.Bool
+}
+
+func (t *requestTrace) clientTrace() *httptrace.ClientTrace {
+	return &httptrace.ClientTrace{
+		GetConn: func(string) {
+			t.connecting.Store(true)
+		},
+		WroteRequest: func(info httptrace.WroteRequestInfo) {
+			if info.Err == nil {
+				t.wrote.Store(true)
+			}
+		},
+	}
+}
+
+// sent reports whether a failed request may have reached the server. Transports that
+// do not report their progress to httptrace are assumed to have sent it, unless the
+// request failed because ctx was done.
+func (t *requestTrace) sent(ctx context.Context) bool {
+	return t.wrote.Load() || (!t.connecting.Load() && ctx.Err() == nil)
 }
 
-// GetJobs retrieves all jobs from the cron-job.org API.
-func (c *Client) GetJobs() ([]Job, error) {
-	resp, err := c.doRequest("GET", "/jobs", nil)
+// GetJobs retrieves all jobs from the cron-job.org API. The API reports when jobs
+// could not be retrieved due to internal errors; such listings are retried up to
+// IncompleteListRetries times. If the last listing is still incomplete, the jobs
+// that were returned are passed on and incomplete is true.
+func (c *Client) GetJobs(ctx context.Context) (jobs []Job, incomplete bool, err error) {
+	for attempt := 0; ; attempt++ {
+		jobsResp, err := c.listJobs(ctx)
+		if err != nil {
+			return nil, false, err
+		}
+		if !jobsResp.SomeFailed || attempt >= c.IncompleteListRetries {
+			return jobsResp.Jobs, jobsResp.SomeFailed, nil
+		}
+		if err := sleepContext(ctx, c.Retry.backoff(attempt+1)); err != nil {
+			return nil, false, fmt.Errorf("incomplete job list (retry aborted: %w)", err)
+		}
+	}
+}
+
+// listJobs performs a single job listing.
+func (c *Client) listJobs(ctx context.Context) (*JobsResponse, error) {
+	resp, err := c.doRequest(ctx, "GET", "/jobs", nil)
 	if err != nil {
 		return nil, err
 	}
@@ -252,13 +431,22 @@ func (c *Client) GetJobs() ([]Job, error) {
 		return nil, fmt.Errorf("failed to decode jobs response: %w", err)
 	}
 
-	return jobsResp.Jobs, nil
+	return &jobsResp, nil
 }
 
-// GetJob retrieves a specific job by ID from the cron-job.org API.
-func (c *Client) GetJob(jobID string) (*Job, error) {
+// GetJob retrieves a specific job by ID from the cron-job.org API. When the job
+// list cache is enabled, the job is served from it if possible.
+func (c *Client) GetJob(ctx context.Context, jobID string) (*Job, error) {
+	job, ok, err := c.ListedJob(ctx, jobID)
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		return job, nil
+	}
+
 	// Use GetJobDetails and convert to Job
-	detailedJob, err := c.GetJobDetails(jobID)
+	detailedJob, err := c.GetJobDetails(ctx, jobID)
 	if err != nil {
 		return nil, err
 	}
@@ -268,8 +456,8 @@ func (c *Client) GetJob(jobID string) (*Job, error) {
 }
 
 // GetJobHistory retrieves the execution history for a specific job.
-func (c *Client) GetJobHistory(jobID string) ([]JobHistory, []int, error) {
-	resp, err := c.doRequest("GET", fmt.Sprintf("/jobs/%s/history", jobID), nil)
+func (c *Client) GetJobHistory(ctx context.Context, jobID string) ([]JobHistory, []int, error) {
+	resp, err := c.doRequest(ctx, "GET", fmt.Sprintf("/jobs/%s/history", jobID), nil)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -283,13 +471,36 @@ func (c *Client) GetJobHistory(jobID string) ([]JobHistory, []int, error) {
 	return historyResp.History, historyResp.Predictions, nil
 }
 
-// CreateJob creates a new cron job.
-func (c *Client) CreateJob(job map[string]interface{}) (int, error) {
-	reqBody := map[string]interface{}{
-		"job": job,
+// GetJobHistoryItem retrieves a single history item of a job, including the
+// response headers and body which are not populated in the history list.
+func (c *Client) GetJobHistoryItem(ctx context.Context, jobID, identifier string) (*JobHistory, error) {
+	resp, err := c.doRequest(ctx, "GET", fmt.Sprintf("/jobs/%s/history/%s", jobID, url.PathEscape(identifier)), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var itemResp JobHistoryItemResponse
+	if err := json.NewDecoder(resp.Body).Decode(&itemResp); err != nil {
+		return nil, fmt.Errorf("failed to decode job history item response: %w", err)
 	}
 
-	resp, err := c.doRequest("PUT", "/jobs", reqBody)
+	return &itemResp.JobHistoryDetails, nil
+}
+
+// CreateJob creates a new cron job and returns its identifier.
+func (c *Client) CreateJob(ctx context.Context, job JobCreate) (int, error) {
+	if err := job.Validate(); err != nil {
+		return 0, markNotSent(err, false)
+	}
+
+	reqBody := struct {
+		Job JobCreate `json:"job"`
+	}{
+		Job: job,
+	}
+
+	resp, err := c.doRequest(ctx, "PUT", "/jobs", reqBody)
 	if err != nil {
 		return 0, err
 	}
@@ -305,33 +516,43 @@ func (c *Client) CreateJob(job map[string]interface{}) (int, error) {
 	return result.JobId, nil
 }
 
-// UpdateJob updates an existing cron job.
-func (c *Client) UpdateJob(jobID string, job map[string]interface{}) error {
-	reqBody := map[string]interface{}{
-		"job": job,
+// UpdateJob updates an existing cron job with the fields set in the patch.
+func (c *Client) UpdateJob(ctx context.Context, jobID string, patch JobPatch) error {
+	if err := patch.Validate(); err != nil {
+		return markNotSent(err, false)
+	}
+
+	reqBody := struct {
+		Job JobPatch `json:"job"`
+	}{
+		Job: patch,
 	}
 
-	_, err := c.doRequest("PATCH", fmt.Sprintf("/jobs/%s", jobID), reqBody)
+	c.JobCache.Evict(jobID)
+	resp, err := c.doRequest(ctx, "PATCH", fmt.Sprintf("/jobs/%s", jobID), reqBody)
 	if err != nil {
 		return err
 	}
+	resp.Body.Close()
 
 	return nil
 }
 
 // DeleteJob deletes a cron job.
-func (c *Client) DeleteJob(jobID string) error {
-	_, err := c.doRequest("DELETE", fmt.Sprintf("/jobs/%s", jobID), nil)
+func (c *Client) DeleteJob(ctx context.Context, jobID string) error {
+	c.JobCache.Evict(jobID)
+	resp, err := c.doRequest(ctx, "DELETE", fmt.Sprintf("/jobs/%s", jobID), nil)
 	if err != nil {
 		return err
 	}
+	resp.Body.Close()
 
 	return nil
 }
 
 // GetJobDetails retrieves detailed information for a specific job.
-func (c *Client) GetJobDetails(jobID string) (*DetailedJob, error) {
-	resp, err := c.doRequest("GET", fmt.Sprintf("/jobs/%s", jobID), nil)
+func (c *Client) GetJobDetails(ctx context.Context, jobID string) (*DetailedJob, error) {
+	resp, err := c.doRequest(ctx, "GET", fmt.Sprintf("/jobs/%s", jobID), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -4,11 +4,14 @@
 package client
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 )
 
 func TestNewClient(t *testing.T) {
@@ -25,8 +28,16 @@ func TestNewClient(t *testing.T) {
 		t.Errorf("Expected APIKey to be %s, got %s", apiKey, client.APIKey)
 	}
 
-	if client.HTTPClient != http.DefaultClient {
-		t.Errorf("Expected HTTPClient to be http.DefaultClient")
+	if client.HTTPClient == nil || client.HTTPClient == http.DefaultClient {
+		t.Fatal("Expected a dedicated HTTP client")
+	}
+
+	if client.HTTPClient.Timeout != DefaultTimeout {
+		t.Errorf("Expected HTTPClient timeout to be %v, got %v", DefaultTimeout, client.HTTPClient.Timeout)
+	}
+
+	if client.UserAgent != DefaultUserAgent {
+		t.Errorf("Expected UserAgent to be %s, got %s", DefaultUserAgent, client.UserAgent)
 	}
 }
 
@@ -56,7 +67,7 @@ func TestDoRequest(t *testing.T) {
 
 	client := NewClient(server.URL, "test-key")
 
-	resp, err := client.doRequest("GET", "/test", nil)
+	resp, err := client.doRequest(context.Background(), "GET", "/test", nil)
 	if err != nil {
 		t.Fatalf("Expected no error, got %v", err)
 	}
@@ -87,7 +98,7 @@ func TestDoRequestWithBody(t *testing.T) {
 		"title": "Test Job",
 	}
 
-	resp, err := client.doRequest("POST", "/test", body)
+	resp, err := client.doRequest(context.Background(), "POST", "/test", body)
 	if err != nil {
 		t.Fatalf("Expected no error, got %v", err)
 	}
@@ -108,7 +119,7 @@ func TestDoRequestAPIError(t *testing.T) {
 
 	client := NewClient(server.URL, "test-key")
 
-	_, err := client.doRequest("GET", "/test", nil)
+	_, err := client.doRequest(context.Background(), "GET", "/test", nil)
 	if err == nil {
 		t.Fatal("Expected an error, got nil")
 	}
@@ -190,11 +201,15 @@ func TestGetJobs(t *testing.T) {
 
 	client := NewClient(server.URL, "test-key")
 
-	jobs, err := client.GetJobs()
+	jobs, incomplete, err := client.GetJobs(context.Background())
 	if err != nil {
 		t.Fatalf("Expected no error, got %v", err)
 	}
 
+	if incomplete {
+		t.Error("Expected a complete job list")
+	}
+
 	if len(jobs) != 2 {
 		t.Errorf("Expected 2 jobs, got %d", len(jobs))
 	}
@@ -212,6 +227,54 @@ func TestGetJobs(t *testing.T) {
 	}
 }
 
+func TestGetJobs_IncompleteRetries(t *testing.T) {
+	testCases := []struct {
+		name               string
+		retries            int
+		incompleteListings int
+		expectedCalls      int
+		expectIncomplete   bool
+	}{
+		{name: "complete after retry", retries: 2, incompleteListings: 1, expectedCalls: 2, expectIncomplete: false},
+		{name: "still incomplete", retries: 2, incompleteListings: 5, expectedCalls: 3, expectIncomplete: true},
+		{name: "retries disabled", retries: 0, incompleteListings: 5, expectedCalls: 1, expectIncomplete: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			calls := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				calls++
+				if calls <= tc.incompleteListings {
+					_, _ = w.Write([]byte(`{"jobs": [{"jobId": 1}], "someFailed": true}`))
+					return
+				}
+				_, _ = w.Write([]byte(`{"jobs": [{"jobId": 1}, {"jobId": 2}], "someFailed": false}`))
+			}))
+			defer server.Close()
+
+			client := NewClient(server.URL, "test-key")
+			client.RateLimiter = nil
+			client.Retry.MinWait = time.Millisecond
+			client.IncompleteListRetries = tc.retries
+
+			jobs, incomplete, err := client.GetJobs(context.Background())
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if calls != tc.expectedCalls {
+				t.Errorf("Expected %d requests, got %d", tc.expectedCalls, calls)
+			}
+			if incomplete != tc.expectIncomplete {
+				t.Errorf("Expected incomplete %v, got %v", tc.expectIncomplete, incomplete)
+			}
+			if tc.expectIncomplete && len(jobs) != 1 {
+				t.Errorf("Expected the partial list of 1 job, got %d", len(jobs))
+			}
+		})
+	}
+}
+
 func TestGetJob(t *testing.T) {
 	// Create a test server
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -272,7 +335,7 @@ func TestGetJob(t *testing.T) {
 
 	client := NewClient(server.URL, "test-key")
 
-	job, err := client.GetJob("1")
+	job, err := client.GetJob(context.Background(), "1")
 	if err != nil {
 		t.Fatalf("Expected no error, got %v", err)
 	}
@@ -286,7 +349,7 @@ func TestGetJob(t *testing.T) {
 	}
 
 	// Test job not found
-	_, err = client.GetJob("999")
+	_, err = client.GetJob(context.Background(), "999")
 	if err == nil {
 		t.Fatal("Expected an error for non-existent job, got nil")
 	}
@@ -365,7 +428,7 @@ func TestGetJobHistory(t *testing.T) {
 
 	client := NewClient(server.URL, "test-key")
 
-	history, predictions, err := client.GetJobHistory("1")
+	history, predictions, err := client.GetJobHistory(context.Background(), "1")
 	if err != nil {
 		t.Fatalf("Expected no error, got %v", err)
 	}
@@ -387,6 +450,68 @@ func TestGetJobHistory(t *testing.T) {
 	}
 }
 
+func TestGetJobHistoryItem(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" {
+			t.Errorf("Expected GET request, got %s", r.Method)
+		}
+		if r.URL.Path != "/jobs/12345/history/12345-22-11-4946" {
+			t.Errorf("Expected path to be /jobs/12345/history/12345-22-11-4946, got %s", r.URL.Path)
+		}
+
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{
+			"jobHistoryDetails": {
+				"jobLogId": 4946,
+				"jobId": 12345,
+				"identifier": "12345-22-11-4946",
+				"date": 1640189711,
+				"datePlanned": 1640189700,
+				"jitter": 11257,
+				"url": "http://example.com/",
+				"duration": 239,
+				"status": 4,
+				"statusText": "HTTP error",
+				"httpStatus": 500,
+				"headers": "Content-Type: text/plain\r\n\r\n",
+				"body": "Internal Server Error",
+				"stats": {
+					"nameLookup": 1003,
+					"connect": 85516,
+					"appConnect": 0,
+					"preTransfer": 85548,
+					"startTransfer": 238112,
+					"total": 238129
+				}
+			}
+		}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key")
+
+	item, err := client.GetJobHistoryItem(context.Background(), "12345", "12345-22-11-4946")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if item.JobLogID != 4946 || item.Identifier != "12345-22-11-4946" {
+		t.Errorf("Unexpected history item: %+v", item)
+	}
+	if !item.Status.IsFailure() || item.HttpStatus != 500 {
+		t.Errorf("Expected failed execution with HTTP status 500, got %v / %d", item.Status, item.HttpStatus)
+	}
+	if item.Headers == nil || *item.Headers != "Content-Type: text/plain\r\n\r\n" {
+		t.Errorf("Expected response headers to be populated, got %v", item.Headers)
+	}
+	if item.Body == nil || *item.Body != "Internal Server Error" {
+		t.Errorf("Expected response body to be populated, got %v", item.Body)
+	}
+	if item.Stats.Total != 238129 {
+		t.Errorf("Expected total transfer time 238129, got %d", item.Stats.Total)
+	}
+}
+
 func TestClient_CreateJob(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != "PUT" {
@@ -419,12 +544,12 @@ func TestClient_CreateJob(t *testing.T) {
 
 	client := NewClient(server.URL, "test-key")
 
-	job := map[string]interface{}{
-		"title": "Test Job",
-		"url":   "https://example.com",
+	job := JobCreate{
+		Title: "Test Job",
+		URL:   "https://example.com",
 	}
 
-	jobID, err := client.CreateJob(job)
+	jobID, err := client.CreateJob(context.Background(), job)
 	if err != nil {
 		t.Fatalf("Expected no error, got %v", err)
 	}
@@ -469,12 +594,14 @@ func TestClient_UpdateJob(t *testing.T) {
 
 	client := NewClient(server.URL, "test-key")
 
-	job := map[string]interface{}{
-		"title": "Updated Job",
-		"url":   "https://example.com/updated",
+	title := "Updated Job"
+	url := "https://example.com/updated"
+	patch := JobPatch{
+		Title: &title,
+		URL:   &url,
 	}
 
-	err := client.UpdateJob("123", job)
+	err := client.UpdateJob(context.Background(), "123", patch)
 	if err != nil {
 		t.Fatalf("Expected no error, got %v", err)
 	}
@@ -501,7 +628,7 @@ func TestClient_DeleteJob(t *testing.T) {
 
 	client := NewClient(server.URL, "test-key")
 
-	err := client.DeleteJob("123")
+	err := client.DeleteJob(context.Background(), "123")
 	if err != nil {
 		t.Fatalf("Expected no error, got %v", err)
 	}
@@ -565,7 +692,7 @@ func TestClient_GetJobDetails(t *testing.T) {
 
 	client := NewClient(server.URL, "test-key")
 
-	details, err := client.GetJobDetails("123")
+	details, err := client.GetJobDetails(context.Background(), "123")
 	if err != nil {
 		t.Fatalf("Expected no error, got %v", err)
 	}
@@ -735,7 +862,7 @@ func TestClient_RealHTTPURLConstruction(t *testing.T) {
 			client.BaseURL = server.URL
 
 			// Make the request
-			_, err := client.doRequest("GET", tc.path, nil)
+			_, err := client.doRequest(context.Background(), "GET", tc.path, nil)
 			if err != nil {
 				t.Fatalf("Unexpected error: %v", err)
 			}
@@ -747,3 +874,45 @@ func TestClient_RealHTTPURLConstruction(t *testing.T) {
 		})
 	}
 }
+
+func TestClient_ContextCancellation(t *testing.T) {
+	requestStarted := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(requestStarted)
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-requestStarted
+		cancel()
+	}()
+
+	_, _, err := client.GetJobs(ctx)
+	if err == nil {
+		t.Fatal("Expected an error after context cancellation, got nil")
+	}
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected error to wrap context.Canceled, got %v", err)
+	}
+}
+
+func TestClient_ContextDeadline(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	err := client.DeleteJob(ctx, "123")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected error to wrap context.DeadlineExceeded, got %v", err)
+	}
+}
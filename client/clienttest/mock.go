@@ -0,0 +1,151 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+// Package clienttest provides a mock of client.JobsAPI for unit tests of code that
+// uses the cron-job.org API.
+package clienttest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/plain-insure/terraform-provider-cronjoborg/client"
+)
+
+// ErrNotImplemented is returned by MockJobsAPI methods whose function is not set.
+var ErrNotImplemented = errors.New("not implemented by MockJobsAPI")
+
+// MockJobsAPI implements client.JobsAPI by calling the function set for each method.
+// Methods whose function is nil return ErrNotImplemented, except ListedJob and
+// JobCacheEnabled, which behave like a client without a job list cache. All calls are
+// recorded in Calls. MockJobsAPI is safe for concurrent use if its functions are.
+type MockJobsAPI struct {
+	GetJobsFunc           func(ctx context.Context) ([]client.Job, bool, error)
+	GetJobFunc            func(ctx context.Context, jobID string) (*client.Job, error)
+	GetJobDetailsFunc     func(ctx context.Context, jobID string) (*client.DetailedJob, error)
+	ListedJobFunc         func(ctx context.Context, jobID string) (*client.Job, bool, error)
+	JobCacheEnabledFunc   func() bool
+	CreateJobFunc         func(ctx context.Context, job client.JobCreate) (int, error)
+	UpdateJobFunc         func(ctx context.Context, jobID string, patch client.JobPatch) error
+	DeleteJobFunc         func(ctx context.Context, jobID string) error
+	GetJobHistoryFunc     func(ctx context.Context, jobID string) ([]client.JobHistory, []int, error)
+	GetJobHistoryItemFunc func(ctx context.Context, jobID, identifier string) (*client.JobHistory, error)
+
+	mu    sync.Mutex
+	calls []string
+}
+
+var _ client.JobsAPI = (*MockJobsAPI)(nil)
+
+// Calls returns the recorded calls in order, formatted as the method name followed by
+// its string arguments, e.g. "GetJobDetails 42".
+func (m *MockJobsAPI) Calls() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]string(nil), m.calls...)
+}
+
+func (m *MockJobsAPI) record(method string, args ...string) {
+	call := method
+	for _, arg := range args {
+		call += " " + arg
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.calls = append(m.calls, call)
+}
+
+func notImplemented(method string) error {
+	return fmt.Errorf("%s: %w", method, ErrNotImplemented)
+}
+
+// GetJobs implements client.JobsAPI.
+func (m *MockJobsAPI) GetJobs(ctx context.Context) ([]client.Job, bool, error) {
+	m.record("GetJobs")
+	if m.GetJobsFunc == nil {
+		return nil, false, notImplemented("GetJobs")
+	}
+	return m.GetJobsFunc(ctx)
+}
+
+// GetJob implements client.JobsAPI.
+func (m *MockJobsAPI) GetJob(ctx context.Context, jobID string) (*client.Job, error) {
+	m.record("GetJob", jobID)
+	if m.GetJobFunc == nil {
+		return nil, notImplemented("GetJob")
+	}
+	return m.GetJobFunc(ctx, jobID)
+}
+
+// GetJobDetails implements client.JobsAPI.
+func (m *MockJobsAPI) GetJobDetails(ctx context.Context, jobID string) (*client.DetailedJob, error) {
+	m.record("GetJobDetails", jobID)
+	if m.GetJobDetailsFunc == nil {
+		return nil, notImplemented("GetJobDetails")
+	}
+	return m.GetJobDetailsFunc(ctx, jobID)
+}
+
+// ListedJob implements client.JobsAPI.
+func (m *MockJobsAPI) ListedJob(ctx context.Context, jobID string) (*client.Job, bool, error) {
+	m.record("ListedJob", jobID)
+	if m.ListedJobFunc == nil {
+		return nil, false, nil
+	}
+	return m.ListedJobFunc(ctx, jobID)
+}
+
+// JobCacheEnabled implements client.JobsAPI.
+func (m *MockJobsAPI) JobCacheEnabled() bool {
+	if m.JobCacheEnabledFunc == nil {
+		return false
+	}
+	return m.JobCacheEnabledFunc()
+}
+
+// CreateJob implements client.JobsAPI.
+func (m *MockJobsAPI) CreateJob(ctx context.Context, job client.JobCreate) (int, error) {
+	m.record("CreateJob")
+	if m.CreateJobFunc == nil {
+		return 0, notImplemented("CreateJob")
+	}
+	return m.CreateJobFunc(ctx, job)
+}
+
+// UpdateJob implements client.JobsAPI.
+func (m *MockJobsAPI) UpdateJob(ctx context.Context, jobID string, patch client.JobPatch) error {
+	m.record("UpdateJob", jobID)
+	if m.UpdateJobFunc == nil {
+		return notImplemented("UpdateJob")
+	}
+	return m.UpdateJobFunc(ctx, jobID, patch)
+}
+
+// DeleteJob implements client.JobsAPI.
+func (m *MockJobsAPI) DeleteJob(ctx context.Context, jobID string) error {
+	m.record("DeleteJob", jobID)
+	if m.DeleteJobFunc == nil {
+		return notImplemented("DeleteJob")
+	}
+	return m.DeleteJobFunc(ctx, jobID)
+}
+
+// GetJobHistory implements client.JobsAPI.
+func (m *MockJobsAPI) GetJobHistory(ctx context.Context, jobID string) ([]client.JobHistory, []int, error) {
+	m.record("GetJobHistory", jobID)
+	if m.GetJobHistoryFunc == nil {
+		return nil, nil, notImplemented("GetJobHistory")
+	}
+	return m.GetJobHistoryFunc(ctx, jobID)
+}
+
+// GetJobHistoryItem implements client.JobsAPI.
+func (m *MockJobsAPI) GetJobHistoryItem(ctx context.Context, jobID, identifier string) (*client.JobHistory, error) {
+	m.record("GetJobHistoryItem", jobID, identifier)
+	if m.GetJobHistoryItemFunc == nil {
+		return nil, notImplemented("GetJobHistoryItem")
+	}
+	return m.GetJobHistoryItemFunc(ctx, jobID, identifier)
+}
@@ -0,0 +1,43 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package clienttest
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/plain-insure/terraform-provider-cronjoborg/client"
+)
+
+func TestMockJobsAPI(t *testing.T) {
+	ctx := context.Background()
+	m := &MockJobsAPI{
+		DeleteJobFunc: func(ctx context.Context, jobID string) error { return nil },
+	}
+
+	if err := m.DeleteJob(ctx, "1"); err != nil {
+		t.Errorf("Expected DeleteJobFunc to be called, got %v", err)
+	}
+	if _, err := m.GetJobDetails(ctx, "1"); !errors.Is(err, ErrNotImplemented) {
+		t.Errorf("Expected ErrNotImplemented, got %v", err)
+	}
+	if err := m.UpdateJob(ctx, "2", client.JobPatch{}); !errors.Is(err, ErrNotImplemented) {
+		t.Errorf("Expected ErrNotImplemented, got %v", err)
+	}
+
+	// Without a job list cache, jobs are never listed.
+	if _, ok, err := m.ListedJob(ctx, "1"); ok || err != nil {
+		t.Errorf("Expected no listed job, got %v, %v", ok, err)
+	}
+	if m.JobCacheEnabled() {
+		t.Error("Expected the job list cache to be disabled")
+	}
+
+	expected := []string{"DeleteJob 1", "GetJobDetails 1", "UpdateJob 2", "ListedJob 1"}
+	if calls := m.Calls(); !reflect.DeepEqual(calls, expected) {
+		t.Errorf("Expected calls %v, got %v", expected, calls)
+	}
+}
@@ -0,0 +1,225 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// cronField describes one field of a five-field cron expression.
+type cronField struct {
+	name  string
+	first int
+	last  int
+	names []string // names[i] is the name of value first+i
+}
+
+var cronFields = []cronField{
+	{name: "minute", first: 0, last: 59},
+	{name: "hour", first: 0, last: 23},
+	{name: "day of month", first: 1, last: 31},
+	{name: "month", first: 1, last: 12, names: []string{"JAN", "FEB", "MAR", "APR", "MAY", "JUN", "JUL", "AUG", "SEP", "OCT", "NOV", "DEC"}},
+	// Day of week 7 is accepted as an alias for Sunday.
+	{name: "day of week", first: 0, last: 7, names: []string{"SUN", "MON", "TUE", "WED", "THU", "FRI", "SAT", "SUN"}},
+}
+
+var cronMacros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// ParseCronExpression parses a five-field cron expression ("minute hour day-of-month
+// month day-of-week") into the time fields of a JobSchedule. Fields may contain
+// lists, ranges, steps and month and weekday names such as "*/15 9-17 * JAN,JUL MON-FRI",
+// and the macros @yearly, @monthly, @weekly, @daily and @hourly are accepted. A field
+// that matches every value becomes the API sentinel [-1]. Timezone and ExpiresAt are
+// left empty.
+//
+// Expressions that restrict both day-of-month and day-of-week are rejected: cron runs
+// them on days that match either field, but the API only on days that match both.
+func ParseCronExpression(expr string) (JobSchedule, error) {
+	var schedule JobSchedule
+
+	trimmed := strings.TrimSpace(expr)
+	if macro, ok := cronMacros[strings.ToLower(trimmed)]; ok {
+		trimmed = macro
+	}
+	parts := strings.Fields(trimmed)
+	if len(parts) != len(cronFields) {
+		return schedule, fmt.Errorf("invalid cron expression %q: expected 5 fields (minute hour day-of-month month day-of-week), got %d", expr, len(parts))
+	}
+
+	values := make([][]int, len(cronFields))
+	for i, field := range cronFields {
+		v, err := field.parse(parts[i])
+		if err != nil {
+			return schedule, fmt.Errorf("invalid cron expression %q: %w", expr, err)
+		}
+		values[i] = v
+	}
+
+	schedule.Minutes, schedule.Hours, schedule.MDays, schedule.Months, schedule.WDays =
+		values[0], values[1], values[2], values[3], values[4]
+	if !slices.Equal(schedule.MDays, []int{-1}) && !slices.Equal(schedule.WDays, []int{-1}) {
+		return JobSchedule{}, fmt.Errorf("invalid cron expression %q: restricting both the day of month and the day of week is not supported, "+
+			"as cron runs the job on days that match either field but cron-job.org only on days that match both; "+
+			"restrict only one of them, or set the mdays and wdays lists to run on days that match both", expr)
+	}
+	return schedule, nil
+}
+
+// CronExpression formats the time fields of the schedule as a five-field cron
+// expression. Consecutive values are written as ranges, and fields that match every
+// value as "*", so schedules that run at the same times format identically.
+func (s JobSchedule) CronExpression() string {
+	fields := [][]int{s.Minutes, s.Hours, s.MDays, s.Months, s.WDays}
+	parts := make([]string, len(fields))
+	for i, values := range fields {
+		parts[i] = cronFields[i].format(values)
+	}
+	return strings.Join(parts, " ")
+}
+
+// parse expands a single field into its sorted values, or [-1] if it matches every value.
+func (f cronField) parse(spec string) ([]int, error) {
+	seen := make(map[int]bool)
+	for _, item := range strings.Split(spec, ",") {
+		if err := f.parseItem(item, seen); err != nil {
+			return nil, err
+		}
+	}
+	return f.normalize(seen), nil
+}
+
+// parseItem adds the values of a list item such as "*", "5", "1-5", "*/15" or "MON-FRI/2".
+func (f cronField) parseItem(item string, seen map[int]bool) error {
+	rangeSpec, stepSpec, hasStep := strings.Cut(item, "/")
+
+	step := 1
+	if hasStep {
+		var err error
+		step, err = strconv.Atoi(stepSpec)
+		if err != nil || step < 1 {
+			return fmt.Errorf("invalid step %q in %s field", stepSpec, f.name)
+		}
+	}
+
+	var start, end int
+	switch {
+	case rangeSpec == "*":
+		start, end = f.first, f.last
+		if f.last == 7 {
+			// Do not count Sunday twice.
+			end = 6
+		}
+	case strings.Contains(rangeSpec, "-"):
+		lo, hi, _ := strings.Cut(rangeSpec, "-")
+		var err error
+		if start, err = f.value(lo); err != nil {
+			return err
+		}
+		if end, err = f.value(hi); err != nil {
+			return err
+		}
+		if start > end {
+			return fmt.Errorf("invalid range %q in %s field: start is after end", rangeSpec, f.name)
+		}
+	default:
+		var err error
+		if start, err = f.value(rangeSpec); err != nil {
+			return err
+		}
+		end = start
+		if hasStep {
+			// "5/15" means every 15 starting at 5.
+			end = f.last
+		}
+	}
+
+	for v := start; v <= end; v += step {
+		seen[v] = true
+	}
+	return nil
+}
+
+// value parses a single number or name of the field.
+func (f cronField) value(spec string) (int, error) {
+	for i, name := range f.names {
+		if strings.EqualFold(spec, name) {
+			return f.first + i, nil
+		}
+	}
+	v, err := strconv.Atoi(spec)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value %q in %s field", spec, f.name)
+	}
+	if v < f.first || v > f.last {
+		return 0, fmt.Errorf("value %d out of range in %s field (%d-%d)", v, f.name, f.first, f.last)
+	}
+	return v, nil
+}
+
+// normalize returns the sorted values of the field, or [-1] if they cover its range.
+func (f cronField) normalize(seen map[int]bool) []int {
+	if f.last == 7 && seen[7] {
+		delete(seen, 7)
+		seen[0] = true
+	}
+
+	values := make([]int, 0, len(seen))
+	for v := range seen {
+		values = append(values, v)
+	}
+	slices.Sort(values)
+
+	last := f.last
+	if last == 7 {
+		last = 6
+	}
+	if len(values) == last-f.first+1 {
+		return []int{-1}
+	}
+	return values
+}
+
+// format writes values as a cron field.
+func (f cronField) format(values []int) string {
+	seen := make(map[int]bool, len(values))
+	for _, v := range values {
+		if v == -1 {
+			return "*"
+		}
+		seen[v] = true
+	}
+	normalized := f.normalize(seen)
+	if len(normalized) == 0 || normalized[0] == -1 {
+		return "*"
+	}
+
+	var parts []string
+	for i := 0; i < len(normalized); {
+		j := i
+		for j+1 < len(normalized) && normalized[j+1] == normalized[j]+1 {
+			j++
+		}
+		switch {
+		case j-i >= 2:
+			parts = append(parts, fmt.Sprintf("%d-%d", normalized[i], normalized[j]))
+		case j > i:
+			parts = append(parts, strconv.Itoa(normalized[i]), strconv.Itoa(normalized[j]))
+		default:
+			parts = append(parts, strconv.Itoa(normalized[i]))
+		}
+		i = j + 1
+	}
+	return strings.Join(parts, ",")
+}
@@ -0,0 +1,127 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseCronExpression(t *testing.T) {
+	tests := []struct {
+		expr string
+		want JobSchedule
+	}{
+		{
+			expr: "* * * * *",
+			want: JobSchedule{Minutes: []int{-1}, Hours: []int{-1}, MDays: []int{-1}, Months: []int{-1}, WDays: []int{-1}},
+		},
+		{
+			expr: "*/15 9-17 * * 1-5",
+			want: JobSchedule{
+				Minutes: []int{0, 15, 30, 45},
+				Hours:   []int{9, 10, 11, 12, 13, 14, 15, 16, 17},
+				MDays:   []int{-1},
+				Months:  []int{-1},
+				WDays:   []int{1, 2, 3, 4, 5},
+			},
+		},
+		{
+			expr: "0,30 3 * jan,JUL MON-FRI/2",
+			want: JobSchedule{Minutes: []int{0, 30}, Hours: []int{3}, MDays: []int{-1}, Months: []int{1, 7}, WDays: []int{1, 3, 5}},
+		},
+		{
+			expr: "5/20 0-23 1-31 1-12 7",
+			want: JobSchedule{Minutes: []int{5, 25, 45}, Hours: []int{-1}, MDays: []int{-1}, Months: []int{-1}, WDays: []int{0}},
+		},
+		{
+			expr: "0 0 * * 0-7",
+			want: JobSchedule{Minutes: []int{0}, Hours: []int{0}, MDays: []int{-1}, Months: []int{-1}, WDays: []int{-1}},
+		},
+		{
+			expr: " @weekly ",
+			want: JobSchedule{Minutes: []int{0}, Hours: []int{0}, MDays: []int{-1}, Months: []int{-1}, WDays: []int{0}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			got, err := ParseCronExpression(tt.expr)
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseCronExpression(%q) = %+v, want %+v", tt.expr, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseCronExpression_Invalid(t *testing.T) {
+	tests := map[string]string{
+		"0 0 * *":       "expected 5 fields",
+		"60 * * * *":    "value 60 out of range in minute field",
+		"* * 0 * *":     "value 0 out of range in day of month field",
+		"* 17-9 * * *":  "start is after end",
+		"*/0 * * * *":   `invalid step "0"`,
+		"* * * FOO *":   `invalid value "FOO" in month field`,
+		"1,,2 * * * *":  `invalid value "" in minute field`,
+		"* * * * MON-X": `invalid value "X" in day of week field`,
+		"0 0 1 * MON":   "restricting both the day of month and the day of week is not supported",
+	}
+
+	for expr, want := range tests {
+		t.Run(expr, func(t *testing.T) {
+			_, err := ParseCronExpression(expr)
+			if err == nil || !strings.Contains(err.Error(), want) {
+				t.Errorf("Expected error containing %q, got %v", want, err)
+			}
+		})
+	}
+}
+
+func TestJobSchedule_CronExpression(t *testing.T) {
+	tests := []struct {
+		schedule JobSchedule
+		want     string
+	}{
+		{
+			schedule: JobSchedule{Minutes: []int{-1}, Hours: []int{-1}, MDays: []int{-1}, Months: []int{-1}, WDays: []int{-1}},
+			want:     "* * * * *",
+		},
+		{
+			schedule: JobSchedule{Minutes: []int{30, 0}, Hours: []int{9, 10, 11, 17}, MDays: nil, Months: []int{1, 2}, WDays: []int{5, 4, 3, 2, 1}},
+			want:     "0,30 9-11,17 * 1,2 1-5",
+		},
+		{
+			schedule: JobSchedule{Minutes: []int{0}, Hours: []int{0}, MDays: []int{-1}, Months: []int{-1}, WDays: []int{0, 1, 2, 3, 4, 5, 6}},
+			want:     "0 0 * * *",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := tt.schedule.CronExpression(); got != tt.want {
+				t.Errorf("CronExpression() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCronExpression_RoundTrip(t *testing.T) {
+	for _, expr := range []string{"*/15 9-17 * * MON-FRI", "0 3 1,15 * *", "@daily", "5 4 * JAN-MAR SUN"} {
+		schedule, err := ParseCronExpression(expr)
+		if err != nil {
+			t.Fatalf("Expected no error for %q, got %v", expr, err)
+		}
+		reparsed, err := ParseCronExpression(schedule.CronExpression())
+		if err != nil {
+			t.Fatalf("Expected the formatted expression %q to parse, got %v", schedule.CronExpression(), err)
+		}
+		if !reflect.DeepEqual(schedule, reparsed) {
+			t.Errorf("%q: round trip through %q changed the schedule from %+v to %+v", expr, schedule.CronExpression(), schedule, reparsed)
+		}
+	}
+}
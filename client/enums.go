@@ -0,0 +1,226 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// JobStatus is the status of a job execution.
+type JobStatus int
+
+const (
+	JobStatusUnknown           JobStatus = 0 // Unknown / not executed yet
+	JobStatusOK                JobStatus = 1 // OK
+	JobStatusFailedDNS         JobStatus = 2 // Failed (DNS error)
+	JobStatusFailedConnect     JobStatus = 3 // Failed (could not connect to host)
+	JobStatusFailedHTTP        JobStatus = 4 // Failed (HTTP error)
+	JobStatusFailedTimeout     JobStatus = 5 // Failed (timeout)
+	JobStatusFailedTooMuchData JobStatus = 6 // Failed (too much response data)
+	JobStatusFailedInvalidURL  JobStatus = 7 // Failed (invalid URL)
+	JobStatusFailedInternal    JobStatus = 8 // Failed (internal errors)
+	JobStatusFailedUnknown     JobStatus = 9 // Failed (unknown reason)
+)
+
+var jobStatusNames = []string{
+	"unknown",
+	"ok",
+	"failed_dns",
+	"failed_connect",
+	"failed_http",
+	"failed_timeout",
+	"failed_too_much_data",
+	"failed_invalid_url",
+	"failed_internal",
+	"failed_unknown",
+}
+
+var jobStatusDescriptions = []string{
+	"Unknown / not executed yet",
+	"OK",
+	"Failed (DNS error)",
+	"Failed (could not connect to host)",
+	"Failed (HTTP error)",
+	"Failed (timeout)",
+	"Failed (too much response data)",
+	"Failed (invalid URL)",
+	"Failed (internal errors)",
+	"Failed (unknown reason)",
+}
+
+// JobStatuses returns all documented job statuses in ascending order.
+func JobStatuses() []JobStatus {
+	statuses := make([]JobStatus, len(jobStatusNames))
+	for i := range jobStatusNames {
+		statuses[i] = JobStatus(i)
+	}
+	return statuses
+}
+
+// Valid reports whether the status is one of the documented values.
+func (s JobStatus) Valid() bool {
+	return s >= JobStatusUnknown && s <= JobStatusFailedUnknown
+}
+
+// IsFailure reports whether the status denotes a failed execution.
+func (s JobStatus) IsFailure() bool {
+	return s >= JobStatusFailedDNS && s <= JobStatusFailedUnknown
+}
+
+// String returns the status identifier, e.g. "failed_http".
+func (s JobStatus) String() string {
+	if !s.Valid() {
+		return "JobStatus(" + strconv.Itoa(int(s)) + ")"
+	}
+	return jobStatusNames[s]
+}
+
+// Description returns the human-readable description of the status as documented by the API.
+func (s JobStatus) Description() string {
+	if !s.Valid() {
+		return s.String()
+	}
+	return jobStatusDescriptions[s]
+}
+
+// ParseJobStatus parses a status identifier as returned by JobStatus.String, or its numeric value.
+func ParseJobStatus(value string) (JobStatus, error) {
+	i, err := parseEnum(value, jobStatusNames)
+	if err != nil {
+		return 0, fmt.Errorf("invalid job status %q", value)
+	}
+	return JobStatus(i), nil
+}
+
+// JobType is the type of a job.
+type JobType int
+
+const (
+	JobTypeDefault    JobType = 0 // Default job
+	JobTypeMonitoring JobType = 1 // Monitoring job (used in a status monitor)
+)
+
+var jobTypeNames = []string{
+	"default",
+	"monitoring",
+}
+
+var jobTypeDescriptions = []string{
+	"Default job",
+	"Monitoring job",
+}
+
+// JobTypes returns all documented job types in ascending order.
+func JobTypes() []JobType {
+	types := make([]JobType, len(jobTypeNames))
+	for i := range jobTypeNames {
+		types[i] = JobType(i)
+	}
+	return types
+}
+
+// Valid reports whether the job type is one of the documented values.
+func (t JobType) Valid() bool {
+	return t >= JobTypeDefault && t <= JobTypeMonitoring
+}
+
+// String returns the job type identifier, e.g. "monitoring".
+func (t JobType) String() string {
+	if !t.Valid() {
+		return "JobType(" + strconv.Itoa(int(t)) + ")"
+	}
+	return jobTypeNames[t]
+}
+
+// Description returns the human-readable description of the job type as documented by the API.
+func (t JobType) Description() string {
+	if !t.Valid() {
+		return t.String()
+	}
+	return jobTypeDescriptions[t]
+}
+
+// ParseJobType parses a job type identifier as returned by JobType.String, or its numeric value.
+func ParseJobType(value string) (JobType, error) {
+	i, err := parseEnum(value, jobTypeNames)
+	if err != nil {
+		return 0, fmt.Errorf("invalid job type %q", value)
+	}
+	return JobType(i), nil
+}
+
+// RequestMethod is the HTTP method used when executing a job.
+type RequestMethod int
+
+const (
+	RequestMethodGet     RequestMethod = 0
+	RequestMethodPost    RequestMethod = 1
+	RequestMethodOptions RequestMethod = 2
+	RequestMethodHead    RequestMethod = 3
+	RequestMethodPut     RequestMethod = 4
+	RequestMethodDelete  RequestMethod = 5
+	RequestMethodTrace   RequestMethod = 6
+	RequestMethodConnect RequestMethod = 7
+	RequestMethodPatch   RequestMethod = 8
+)
+
+var requestMethodNames = []string{
+	"GET",
+	"POST",
+	"OPTIONS",
+	"HEAD",
+	"PUT",
+	"DELETE",
+	"TRACE",
+	"CONNECT",
+	"PATCH",
+}
+
+// RequestMethods returns all documented request methods in ascending order.
+func RequestMethods() []RequestMethod {
+	methods := make([]RequestMethod, len(requestMethodNames))
+	for i := range requestMethodNames {
+		methods[i] = RequestMethod(i)
+	}
+	return methods
+}
+
+// Valid reports whether the request method is one of the documented values.
+func (m RequestMethod) Valid() bool {
+	return m >= RequestMethodGet && m <= RequestMethodPatch
+}
+
+// String returns the HTTP method name, e.g. "POST".
+func (m RequestMethod) String() string {
+	if !m.Valid() {
+		return "RequestMethod(" + strconv.Itoa(int(m)) + ")"
+	}
+	return requestMethodNames[m]
+}
+
+// ParseRequestMethod parses an HTTP method name (case-insensitive), or its numeric value.
+func ParseRequestMethod(value string) (RequestMethod, error) {
+	i, err := parseEnum(value, requestMethodNames)
+	if err != nil {
+		return 0, fmt.Errorf("invalid request method %q", value)
+	}
+	return RequestMethod(i), nil
+}
+
+// parseEnum returns the index of value in names, compared case-insensitively.
+// Numeric values within range are accepted as well.
+func parseEnum(value string, names []string) (int, error) {
+	value = strings.TrimSpace(value)
+	for i, name := range names {
+		if strings.EqualFold(value, name) {
+			return i, nil
+		}
+	}
+	if i, err := strconv.Atoi(value); err == nil && i >= 0 && i < len(names) {
+		return i, nil
+	}
+	return 0, fmt.Errorf("unknown value %q", value)
+}
@@ -0,0 +1,134 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+func TestJobStatus(t *testing.T) {
+	testCases := []struct {
+		status      JobStatus
+		name        string
+		description string
+		failure     bool
+	}{
+		{JobStatusUnknown, "unknown", "Unknown / not executed yet", false},
+		{JobStatusOK, "ok", "OK", false},
+		{JobStatusFailedDNS, "failed_dns", "Failed (DNS error)", true},
+		{JobStatusFailedHTTP, "failed_http", "Failed (HTTP error)", true},
+		{JobStatusFailedUnknown, "failed_unknown", "Failed (unknown reason)", true},
+		{JobStatus(42), "JobStatus(42)", "JobStatus(42)", false},
+	}
+
+	for _, tc := range testCases {
+		if got := tc.status.String(); got != tc.name {
+			t.Errorf("JobStatus(%d).String(): expected %q, got %q", int(tc.status), tc.name, got)
+		}
+		if got := tc.status.Description(); got != tc.description {
+			t.Errorf("JobStatus(%d).Description(): expected %q, got %q", int(tc.status), tc.description, got)
+		}
+		if got := tc.status.IsFailure(); got != tc.failure {
+			t.Errorf("JobStatus(%d).IsFailure(): expected %v, got %v", int(tc.status), tc.failure, got)
+		}
+	}
+
+	if len(JobStatuses()) != 10 {
+		t.Errorf("Expected 10 documented job statuses, got %d", len(JobStatuses()))
+	}
+}
+
+func TestParseEnums(t *testing.T) {
+	for _, value := range []string{"failed_timeout", "FAILED_TIMEOUT", "5"} {
+		status, err := ParseJobStatus(value)
+		if err != nil || status != JobStatusFailedTimeout {
+			t.Errorf("ParseJobStatus(%q): expected %v, got %v, %v", value, JobStatusFailedTimeout, status, err)
+		}
+	}
+	for _, value := range []string{"monitoring", "1"} {
+		jobType, err := ParseJobType(value)
+		if err != nil || jobType != JobTypeMonitoring {
+			t.Errorf("ParseJobType(%q): expected %v, got %v, %v", value, JobTypeMonitoring, jobType, err)
+		}
+	}
+	for _, value := range []string{"PATCH", "patch", " Patch ", "8"} {
+		method, err := ParseRequestMethod(value)
+		if err != nil || method != RequestMethodPatch {
+			t.Errorf("ParseRequestMethod(%q): expected %v, got %v, %v", value, RequestMethodPatch, method, err)
+		}
+	}
+
+	for _, value := range []string{"", "FETCH", "9", "-1"} {
+		if _, err := ParseRequestMethod(value); err == nil {
+			t.Errorf("ParseRequestMethod(%q): expected an error", value)
+		}
+	}
+	if _, err := ParseJobStatus("10"); err == nil {
+		t.Error("ParseJobStatus(\"10\"): expected an error")
+	}
+	if _, err := ParseJobType("paused"); err == nil {
+		t.Error("ParseJobType(\"paused\"): expected an error")
+	}
+}
+
+func TestRequestMethod_String(t *testing.T) {
+	for i, method := range RequestMethods() {
+		if int(method) != i || !method.Valid() {
+			t.Errorf("RequestMethods()[%d]: expected valid method %d, got %d", i, i, int(method))
+		}
+	}
+	if got := RequestMethodDelete.String(); got != "DELETE" {
+		t.Errorf("Expected DELETE, got %q", got)
+	}
+	if RequestMethod(9).Valid() || RequestMethod(-1).Valid() {
+		t.Error("Expected out-of-range request methods to be invalid")
+	}
+}
+
+func TestJob_UnmarshalEnums(t *testing.T) {
+	var job Job
+	if err := json.Unmarshal([]byte(`{"jobId": 1, "lastStatus": 4, "type": 1, "requestMethod": 1}`), &job); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if job.LastStatus != JobStatusFailedHTTP || !job.LastStatus.IsFailure() {
+		t.Errorf("Expected last status failed_http, got %v", job.LastStatus)
+	}
+	if job.Type != JobTypeMonitoring {
+		t.Errorf("Expected monitoring job, got %v", job.Type)
+	}
+	if job.RequestMethod != RequestMethodPost {
+		t.Errorf("Expected POST, got %v", job.RequestMethod)
+	}
+}
+
+func TestClient_RejectsInvalidRequestMethod(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"jobId": 1}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key")
+	client.RateLimiter = nil
+
+	if _, err := client.CreateJob(context.Background(), JobCreate{URL: "https://example.com", RequestMethod: RequestMethod(9)}); err == nil {
+		t.Error("Expected CreateJob to reject an out-of-range request method")
+	}
+
+	method := RequestMethod(-1)
+	if err := client.UpdateJob(context.Background(), "1", JobPatch{RequestMethod: &method}); err == nil {
+		t.Error("Expected UpdateJob to reject an out-of-range request method")
+	}
+
+	if calls != 0 {
+		t.Errorf("Expected no requests to be sent, got %d", calls)
+	}
+}
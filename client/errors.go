@@ -0,0 +1,133 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Sentinel errors for the documented API status codes. An *APIError matches
+// the sentinel of its class with errors.Is, also when it has been wrapped.
+var (
+	// ErrBadRequest is returned for 400 responses (invalid request or input data).
+	ErrBadRequest = errors.New("bad request")
+	// ErrUnauthorized is returned for 401 responses (invalid API key).
+	ErrUnauthorized = errors.New("unauthorized")
+	// ErrForbiddenIP is returned for 403 responses, which the API sends when the
+	// API key is restricted to IP addresses that do not include the caller's.
+	ErrForbiddenIP = errors.New("API key cannot be used from this IP address")
+	// ErrNotFound is returned for 404 responses.
+	ErrNotFound = errors.New("not found")
+	// ErrConflict is returned for 409 responses, e.g. because a resource already exists.
+	ErrConflict = errors.New("conflict")
+	// ErrRateLimited is returned for 429 responses caused by the per-endpoint rate limits.
+	ErrRateLimited = errors.New("rate limit exceeded")
+	// ErrQuotaExceeded is returned for 429 responses caused by the API key's request
+	// quota or the account's resource quota rather than the rate limit.
+	ErrQuotaExceeded = errors.New("quota exceeded")
+	// ErrServerError is returned for 5xx responses.
+	ErrServerError = errors.New("server error")
+)
+
+// ErrNotSent is matched by errors of requests that failed before they were written to
+// the network, e.g. because the request was invalid, the context was cancelled while
+// waiting for the rate limiter or the API host could not be reached. The API cannot
+// have acted on such requests.
+var ErrNotSent = errors.New("request was not sent")
+
+// notSentError marks an error as matching ErrNotSent without changing its message.
+type notSentError struct {
+	err error
+}
+
+func (e *notSentError) Error() string {
+	return e.err.Error()
+}
+
+func (e *notSentError) Unwrap() []error {
+	return []error{e.err, ErrNotSent}
+}
+
+// markNotSent wraps err so that it matches ErrNotSent unless the request was sent.
+func markNotSent(err error, sent bool) error {
+	if sent {
+		return err
+	}
+	return &notSentError{err: err}
+}
+
+// APIError represents an error response from the API.
+type APIError struct {
+	// Method and Path identify the request that failed, e.g. "PATCH" and "/jobs/123".
+	Method     string
+	Path       string
+	StatusCode int
+	Message    string
+	Body       string
+
+	// retryAfter holds the delay requested by the server via the Retry-After header.
+	retryAfter time.Duration
+}
+
+func (e *APIError) Error() string {
+	if e.Method == "" {
+		return fmt.Sprintf("API error %d: %s", e.StatusCode, e.Message)
+	}
+	return fmt.Sprintf("API error %d on %s %s: %s", e.StatusCode, e.Method, e.Path, e.Message)
+}
+
+// Is reports whether target is the sentinel error for the class of this error.
+func (e *APIError) Is(target error) bool {
+	return target != nil && e.sentinel() == target
+}
+
+// sentinel returns the sentinel error matching the status code, or nil for undocumented codes.
+func (e *APIError) sentinel() error {
+	switch {
+	case e.StatusCode == http.StatusBadRequest:
+		return ErrBadRequest
+	case e.StatusCode == http.StatusUnauthorized:
+		return ErrUnauthorized
+	case e.StatusCode == http.StatusForbidden:
+		return ErrForbiddenIP
+	case e.StatusCode == http.StatusNotFound:
+		return ErrNotFound
+	case e.StatusCode == http.StatusConflict:
+		return ErrConflict
+	case e.StatusCode == http.StatusTooManyRequests && e.isQuota():
+		return ErrQuotaExceeded
+	case e.StatusCode == http.StatusTooManyRequests:
+		return ErrRateLimited
+	case e.StatusCode >= 500:
+		return ErrServerError
+	default:
+		return nil
+	}
+}
+
+// isQuota reports whether a 429 response was caused by a quota rather than the rate limit.
+// The API uses the same status code for both and only the message tells them apart.
+func (e *APIError) isQuota() bool {
+	return strings.Contains(strings.ToLower(e.Message), "quota") ||
+		strings.Contains(strings.ToLower(e.Body), "quota")
+}
+
+// Retryable reports whether repeating the request may succeed. Rate-limited requests
+// were not processed and can always be repeated; server errors only for idempotent
+// methods, since the server may have applied the request before failing. Exceeded
+// quotas do not reset within a retry window and are never retryable.
+func (e *APIError) Retryable() bool {
+	switch e.sentinel() {
+	case ErrRateLimited:
+		return true
+	case ErrServerError:
+		return isIdempotentMethod(e.Method)
+	default:
+		return false
+	}
+}
@@ -0,0 +1,229 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestAPIError_Is(t *testing.T) {
+	sentinels := []error{
+		ErrBadRequest, ErrUnauthorized, ErrForbiddenIP, ErrNotFound,
+		ErrConflict, ErrRateLimited, ErrQuotaExceeded, ErrServerError,
+	}
+
+	testCases := []struct {
+		err      *APIError
+		expected error
+	}{
+		{&APIError{StatusCode: 400}, ErrBadRequest},
+		{&APIError{StatusCode: 401}, ErrUnauthorized},
+		{&APIError{StatusCode: 403}, ErrForbiddenIP},
+		{&APIError{StatusCode: 404}, ErrNotFound},
+		{&APIError{StatusCode: 409}, ErrConflict},
+		{&APIError{StatusCode: 429, Message: "Too many requests"}, ErrRateLimited},
+		{&APIError{StatusCode: 429, Message: "API key quota exceeded"}, ErrQuotaExceeded},
+		{&APIError{StatusCode: 429, Body: `{"error": "Job quota reached"}`}, ErrQuotaExceeded},
+		{&APIError{StatusCode: 500}, ErrServerError},
+		{&APIError{StatusCode: 503}, ErrServerError},
+		{&APIError{StatusCode: 418}, nil},
+	}
+
+	for _, tc := range testCases {
+		// Matching must also work through wrapping.
+		err := fmt.Errorf("wrapped: %w", tc.err)
+		for _, sentinel := range sentinels {
+			if got, want := errors.Is(err, sentinel), sentinel == tc.expected; got != want {
+				t.Errorf("status %d (%q): errors.Is(err, %q) = %v, expected %v", tc.err.StatusCode, tc.err.Message, sentinel, got, want)
+			}
+		}
+	}
+}
+
+func TestAPIError_Retryable(t *testing.T) {
+	testCases := []struct {
+		err      *APIError
+		expected bool
+	}{
+		{&APIError{Method: http.MethodGet, StatusCode: 429}, true},
+		{&APIError{Method: http.MethodPut, StatusCode: 429}, true},
+		{&APIError{Method: http.MethodGet, StatusCode: 429, Message: "quota exceeded"}, false},
+		{&APIError{Method: http.MethodGet, StatusCode: 502}, true},
+		{&APIError{Method: http.MethodDelete, StatusCode: 500}, true},
+		{&APIError{Method: http.MethodPut, StatusCode: 500}, false},
+		{&APIError{Method: http.MethodGet, StatusCode: 404}, false},
+		{&APIError{Method: http.MethodPatch, StatusCode: 400}, false},
+	}
+
+	for _, tc := range testCases {
+		if got := tc.err.Retryable(); got != tc.expected {
+			t.Errorf("%s %d (%q): expected Retryable() = %v, got %v", tc.err.Method, tc.err.StatusCode, tc.err.Message, tc.expected, got)
+		}
+	}
+}
+
+func TestAPIError_ErrorIncludesRequest(t *testing.T) {
+	err := &APIError{Method: "PATCH", Path: "/jobs/123", StatusCode: 403, Message: "Forbidden"}
+
+	expected := "API error 403 on PATCH /jobs/123: Forbidden"
+	if err.Error() != expected {
+		t.Errorf("Expected error string '%s', got '%s'", expected, err.Error())
+	}
+}
+
+func TestClient_ErrorCarriesRequest(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"error": "Job not found"}`))
+	}))
+	defer server.Close()
+
+	client := newTestRetryClient(server.URL, 1)
+
+	_, err := client.GetJobDetails(context.Background(), "123")
+	if !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Expected ErrNotFound, got %v", err)
+	}
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("Expected *APIError, got %T", err)
+	}
+	if apiErr.Method != http.MethodGet || apiErr.Path != "/jobs/123" {
+		t.Errorf("Expected GET /jobs/123, got %s %s", apiErr.Method, apiErr.Path)
+	}
+}
+
+func TestRetry_QuotaExceededNotRetried(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusTooManyRequests)
+		_, _ = w.Write([]byte(`{"error": "Daily API quota exceeded"}`))
+	}))
+	defer server.Close()
+
+	client := newTestRetryClient(server.URL, 4)
+
+	_, _, err := client.GetJobs(context.Background())
+	if !errors.Is(err, ErrQuotaExceeded) {
+		t.Fatalf("Expected ErrQuotaExceeded, got %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("Expected 1 attempt for an exceeded quota, got %d", calls)
+	}
+}
+
+func TestClient_ErrNotSent(t *testing.T) {
+	closed := httptest.NewServer(http.NotFoundHandler())
+	closed.Close()
+
+	dropped := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, _, err := w.(http.Hijacker).Hijack()
+		if err == nil {
+			conn.Close()
+		}
+	}))
+	defer dropped.Close()
+
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer failing.Close()
+
+	job := JobCreate{Title: "Job", URL: "https://example.com"}
+
+	tests := []struct {
+		name    string
+		create  func() error
+		notSent bool
+	}{
+		{
+			name: "invalid job",
+			create: func() error {
+				_, err := NewClient(failing.URL, "test-key").CreateJob(context.Background(), JobCreate{RequestMethod: RequestMethod(99)})
+				return err
+			},
+			notSent: true,
+		},
+		{
+			name: "cancelled context",
+			create: func() error {
+				ctx, cancel := context.WithCancel(context.Background())
+				cancel()
+				_, err := NewClient(failing.URL, "test-key").CreateJob(ctx, job)
+				return err
+			},
+			notSent: true,
+		},
+		{
+			name: "rate limiter wait aborted",
+			create: func() error {
+				c := NewClient(failing.URL, "test-key")
+				c.RateLimiter = NewRateLimiter(DefaultRateLimits())
+				_, _ = c.CreateJob(context.Background(), job)
+
+				ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+				defer cancel()
+				_, err := c.CreateJob(ctx, job)
+				return err
+			},
+			notSent: true,
+		},
+		{
+			name: "budget exhausted",
+			create: func() error {
+				c := NewClient(failing.URL, "test-key")
+				c.Quota = NewQuotaTracker("test-key", QuotaBudget{MaxPerRun: 1})
+				c.Retry.MaxAttempts = 1
+				_ = c.DeleteJob(context.Background(), "1")
+				_, err := c.CreateJob(context.Background(), job)
+				return err
+			},
+			notSent: true,
+		},
+		{
+			name: "host unreachable",
+			create: func() error {
+				// Retrying is safe but would only slow the test down.
+				_, err := NewClient(closed.URL, "test-key", WithRetry(RetryPolicy{MaxAttempts: 1})).CreateJob(context.Background(), job)
+				return err
+			},
+			notSent: true,
+		},
+		{
+			name: "connection dropped after the request",
+			create: func() error {
+				_, err := NewClient(dropped.URL, "test-key").CreateJob(context.Background(), job)
+				return err
+			},
+		},
+		{
+			name: "server error",
+			create: func() error {
+				_, err := NewClient(failing.URL, "test-key").CreateJob(context.Background(), job)
+				return err
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.create()
+			if err == nil {
+				t.Fatal("Expected an error")
+			}
+			if got := errors.Is(err, ErrNotSent); got != tt.notSent {
+				t.Errorf("errors.Is(%v, ErrNotSent) = %t, want %t", err, got, tt.notSent)
+			}
+		})
+	}
+}
@@ -0,0 +1,169 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+)
+
+// JobCreate is the job payload of a create request (PUT /jobs). All scalar fields
+// are sent as-is; nil sub-objects are omitted so the API applies its defaults.
+type JobCreate struct {
+	Enabled         bool                     `json:"enabled"`
+	Title           string                   `json:"title"`
+	SaveResponses   bool                     `json:"saveResponses"`
+	URL             string                   `json:"url"`
+	RequestTimeout  int                      `json:"requestTimeout"`
+	RedirectSuccess bool                     `json:"redirectSuccess"`
+	FolderID        int                      `json:"folderId"`
+	Schedule        *JobSchedule             `json:"schedule,omitempty"`
+	RequestMethod   RequestMethod            `json:"requestMethod"`
+	Auth            *JobAuth                 `json:"auth,omitempty"`
+	Notification    *JobNotificationSettings `json:"notification,omitempty"`
+	ExtendedData    *JobExtendedData         `json:"extendedData,omitempty"`
+}
+
+// JobPatch is the job delta of an update request (PATCH /jobs/<jobId>).
+// Only non-nil fields are sent; all other fields keep their current value.
+type JobPatch struct {
+	Enabled         *bool                    `json:"enabled,omitempty"`
+	Title           *string                  `json:"title,omitempty"`
+	SaveResponses   *bool                    `json:"saveResponses,omitempty"`
+	URL             *string                  `json:"url,omitempty"`
+	RequestTimeout  *int                     `json:"requestTimeout,omitempty"`
+	RedirectSuccess *bool                    `json:"redirectSuccess,omitempty"`
+	FolderID        *int                     `json:"folderId,omitempty"`
+	Schedule        *JobSchedule             `json:"schedule,omitempty"`
+	RequestMethod   *RequestMethod           `json:"requestMethod,omitempty"`
+	Auth            *JobAuth                 `json:"auth,omitempty"`
+	Notification    *JobNotificationSettings `json:"notification,omitempty"`
+	ExtendedData    *JobExtendedData         `json:"extendedData,omitempty"`
+}
+
+// IsEmpty reports whether the patch does not change any field.
+func (p JobPatch) IsEmpty() bool {
+	return p == JobPatch{}
+}
+
+// Patch returns a patch that sets every field of an existing job to the values of j.
+// Nil sub-objects of j are sent empty, resetting the job's settings instead of
+// keeping them.
+func (j JobCreate) Patch() JobPatch {
+	patch := JobPatch{
+		Enabled:         &j.Enabled,
+		Title:           &j.Title,
+		SaveResponses:   &j.SaveResponses,
+		URL:             &j.URL,
+		RequestTimeout:  &j.RequestTimeout,
+		RedirectSuccess: &j.RedirectSuccess,
+		FolderID:        &j.FolderID,
+		Schedule:        j.Schedule,
+		RequestMethod:   &j.RequestMethod,
+		Auth:            j.Auth,
+		Notification:    j.Notification,
+		ExtendedData:    j.ExtendedData,
+	}
+	if patch.Auth == nil {
+		patch.Auth = &JobAuth{}
+	}
+	if patch.Notification == nil {
+		patch.Notification = &JobNotificationSettings{}
+	}
+	if patch.ExtendedData == nil {
+		patch.ExtendedData = &JobExtendedData{}
+	}
+	return patch
+}
+
+// Validate reports an error if the payload contains a value the API does not accept.
+func (j JobCreate) Validate() error {
+	if !j.RequestMethod.Valid() {
+		return fmt.Errorf("invalid job: request method %d is out of range", j.RequestMethod)
+	}
+	return nil
+}
+
+// Validate reports an error if the patch contains a value the API does not accept.
+func (p JobPatch) Validate() error {
+	if p.RequestMethod != nil && !p.RequestMethod.Valid() {
+		return fmt.Errorf("invalid job patch: request method %d is out of range", *p.RequestMethod)
+	}
+	return nil
+}
+
+// MarshalJSON always encodes headers as an object, since the API does not accept null.
+func (j JobExtendedData) MarshalJSON() ([]byte, error) {
+	headers := j.Headers
+	if headers == nil {
+		headers = map[string]string{}
+	}
+	return json.Marshal(struct {
+		Headers map[string]string `json:"headers"`
+		Body    string            `json:"body"`
+	}{
+		Headers: headers,
+		Body:    j.Body,
+	})
+}
+
+// Equal reports whether two schedules describe the same settings. The time lists
+// are compared as sets, so their order and duplicates do not matter.
+func (s JobSchedule) Equal(other JobSchedule) bool {
+	s, other = s.Normalize(), other.Normalize()
+	return s.Timezone == other.Timezone &&
+		s.ExpiresAt == other.ExpiresAt &&
+		slices.Equal(s.Hours, other.Hours) &&
+		slices.Equal(s.MDays, other.MDays) &&
+		slices.Equal(s.Minutes, other.Minutes) &&
+		slices.Equal(s.Months, other.Months) &&
+		slices.Equal(s.WDays, other.WDays)
+}
+
+// DiffJobs returns the minimal patch that turns oldJob into newJob. Read-only
+// fields such as the job ID, type and execution status are ignored.
+func DiffJobs(oldJob, newJob DetailedJob) JobPatch {
+	var patch JobPatch
+
+	if oldJob.Enabled != newJob.Enabled {
+		patch.Enabled = &newJob.Enabled
+	}
+	if oldJob.Title != newJob.Title {
+		patch.Title = &newJob.Title
+	}
+	if oldJob.SaveResponses != newJob.SaveResponses {
+		patch.SaveResponses = &newJob.SaveResponses
+	}
+	if oldJob.URL != newJob.URL {
+		patch.URL = &newJob.URL
+	}
+	if oldJob.RequestTimeout != newJob.RequestTimeout {
+		patch.RequestTimeout = &newJob.RequestTimeout
+	}
+	if oldJob.RedirectSuccess != newJob.RedirectSuccess {
+		patch.RedirectSuccess = &newJob.RedirectSuccess
+	}
+	if oldJob.FolderID != newJob.FolderID {
+		patch.FolderID = &newJob.FolderID
+	}
+	if !oldJob.Schedule.Equal(newJob.Schedule) {
+		patch.Schedule = &newJob.Schedule
+	}
+	if oldJob.RequestMethod != newJob.RequestMethod {
+		patch.RequestMethod = &newJob.RequestMethod
+	}
+	if oldJob.Auth != newJob.Auth {
+		patch.Auth = &newJob.Auth
+	}
+	if oldJob.Notification != newJob.Notification {
+		patch.Notification = &newJob.Notification
+	}
+	if oldJob.ExtendedData.Body != newJob.ExtendedData.Body || !maps.Equal(oldJob.ExtendedData.Headers, newJob.ExtendedData.Headers) {
+		patch.ExtendedData = &newJob.ExtendedData
+	}
+
+	return patch
+}
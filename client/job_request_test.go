@@ -0,0 +1,160 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestJobPatch_MarshalOmitsUnsetFields(t *testing.T) {
+	enabled := false
+	patch := JobPatch{Enabled: &enabled}
+
+	data, err := json.Marshal(patch)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if string(data) != `{"enabled":false}` {
+		t.Errorf("Expected only the enabled field to be sent, got %s", data)
+	}
+}
+
+func TestJobPatch_IsEmpty(t *testing.T) {
+	if !(JobPatch{}).IsEmpty() {
+		t.Error("Expected zero patch to be empty")
+	}
+
+	title := "Title"
+	if (JobPatch{Title: &title}).IsEmpty() {
+		t.Error("Expected patch with title to not be empty")
+	}
+}
+
+func TestJobCreate_MarshalOmitsUnsetSubObjects(t *testing.T) {
+	data, err := json.Marshal(JobCreate{URL: "https://example.com", RequestTimeout: -1})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	var fields map[string]interface{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		t.Fatalf("Expected valid JSON, got %v", err)
+	}
+
+	for _, key := range []string{"schedule", "auth", "notification", "extendedData"} {
+		if _, exists := fields[key]; exists {
+			t.Errorf("Expected %s to be omitted, got %s", key, data)
+		}
+	}
+	for _, key := range []string{"url", "title", "enabled", "requestTimeout", "folderId", "requestMethod"} {
+		if _, exists := fields[key]; !exists {
+			t.Errorf("Expected %s to be sent, got %s", key, data)
+		}
+	}
+}
+
+func TestJobCreate_Patch(t *testing.T) {
+	job := JobCreate{
+		Title:          "Job",
+		URL:            "https://example.com",
+		RequestTimeout: -1,
+		Schedule:       &JobSchedule{Timezone: "UTC", Hours: []int{-1}},
+		Auth:           &JobAuth{Enable: true, User: "user"},
+	}
+
+	patch := job.Patch()
+	if patch.Title == nil || *patch.Title != "Job" || patch.Enabled == nil || *patch.Enabled {
+		t.Errorf("Expected all scalar fields to be set, got %+v", patch)
+	}
+	if patch.Schedule != job.Schedule || patch.Auth != job.Auth {
+		t.Errorf("Expected the configured sub-objects to be sent, got %+v", patch)
+	}
+	if patch.Notification == nil || *patch.Notification != (JobNotificationSettings{}) {
+		t.Errorf("Expected notification settings to be reset, got %+v", patch.Notification)
+	}
+	if patch.ExtendedData == nil || patch.ExtendedData.Body != "" || len(patch.ExtendedData.Headers) != 0 {
+		t.Errorf("Expected extended data to be reset, got %+v", patch.ExtendedData)
+	}
+}
+
+func TestJobExtendedData_MarshalNilHeaders(t *testing.T) {
+	data, err := json.Marshal(JobExtendedData{Body: "hello"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if string(data) != `{"headers":{},"body":"hello"}` {
+		t.Errorf("Expected headers to be encoded as an empty object, got %s", data)
+	}
+}
+
+func TestDiffJobs(t *testing.T) {
+	base := DetailedJob{
+		Job: Job{
+			JobID:          1,
+			Title:          "Job",
+			URL:            "https://example.com",
+			RequestTimeout: -1,
+			Schedule: JobSchedule{
+				Timezone: "UTC",
+				Hours:    []int{-1},
+				MDays:    []int{-1},
+				Minutes:  []int{0},
+				Months:   []int{-1},
+				WDays:    []int{-1},
+			},
+		},
+		ExtendedData: JobExtendedData{Headers: map[string]string{}},
+	}
+
+	t.Run("identical jobs", func(t *testing.T) {
+		other := base
+		other.ExtendedData.Headers = nil
+		other.LastStatus = JobStatusFailedHTTP // read-only fields are ignored
+		other.Schedule.Hours = []int{}         // equivalent to [-1]
+
+		if patch := DiffJobs(base, other); !patch.IsEmpty() {
+			t.Errorf("Expected empty patch, got %+v", patch)
+		}
+	})
+
+	t.Run("changed fields", func(t *testing.T) {
+		other := base
+		other.Enabled = true
+		other.Schedule.Minutes = []int{0, 30}
+		other.Auth = JobAuth{Enable: true, User: "user", Password: "secret"}
+		other.ExtendedData = JobExtendedData{Headers: map[string]string{"X-Foo": "Bar"}}
+
+		patch := DiffJobs(base, other)
+
+		if patch.Enabled == nil || !*patch.Enabled {
+			t.Errorf("Expected enabled to be patched to true, got %v", patch.Enabled)
+		}
+		if patch.Schedule == nil || len(patch.Schedule.Minutes) != 2 {
+			t.Errorf("Expected schedule to be patched, got %+v", patch.Schedule)
+		}
+		if patch.Auth == nil || patch.Auth.User != "user" {
+			t.Errorf("Expected auth to be patched, got %+v", patch.Auth)
+		}
+		if patch.ExtendedData == nil || patch.ExtendedData.Headers["X-Foo"] != "Bar" {
+			t.Errorf("Expected extendedData to be patched, got %+v", patch.ExtendedData)
+		}
+		if patch.Title != nil || patch.URL != nil || patch.Notification != nil || patch.RequestTimeout != nil {
+			t.Errorf("Expected unchanged fields to be omitted, got %+v", patch)
+		}
+	})
+}
+
+func TestJobSchedule_Equal(t *testing.T) {
+	schedule := JobSchedule{Timezone: "UTC", Hours: []int{9, 17}, Minutes: []int{0}}
+
+	if !schedule.Equal(JobSchedule{Timezone: "UTC", Hours: []int{17, 9, 9}, MDays: []int{-1}, Minutes: []int{0}}) {
+		t.Error("Expected time lists to be compared as sets")
+	}
+	if schedule.Equal(JobSchedule{Timezone: "UTC", Hours: []int{9}, Minutes: []int{0}}) {
+		t.Error("Expected different hours not to be equal")
+	}
+}
@@ -0,0 +1,88 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"strconv"
+	"sync"
+)
+
+// JobListCache shares a single GET /jobs response between all readers of a client,
+// so that refreshing many jobs costs one request instead of one per job. The list
+// is loaded on first use and kept for the lifetime of the cache; jobs written through
+// the client are evicted for good, so that later reads fetch their details again even
+// if the list is loaded while the write is in flight.
+//
+// A nil JobListCache disables caching.
+type JobListCache struct {
+	mu      sync.Mutex
+	loaded  bool
+	jobs    map[string]Job
+	evicted map[string]bool
+}
+
+// NewJobListCache returns an empty cache.
+func NewJobListCache() *JobListCache {
+	return &JobListCache{}
+}
+
+// Lookup returns the listed job with the given ID, loading the list with load on
+// first use. Concurrent callers wait for a single load. A failed load is not cached.
+func (l *JobListCache) Lookup(ctx context.Context, jobID string, load func(ctx context.Context) ([]Job, error)) (*Job, bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if !l.loaded {
+		jobs, err := load(ctx)
+		if err != nil {
+			return nil, false, err
+		}
+		l.jobs = make(map[string]Job, len(jobs))
+		for _, job := range jobs {
+			l.jobs[strconv.Itoa(job.JobID)] = job
+		}
+		l.loaded = true
+	}
+
+	job, ok := l.jobs[jobID]
+	if !ok || l.evicted[jobID] {
+		return nil, false, nil
+	}
+	return &job, true, nil
+}
+
+// Evict removes a job from the cache for the lifetime of the cache. It is a no-op on
+// a nil cache.
+func (l *JobListCache) Evict(jobID string) {
+	if l == nil {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.evicted == nil {
+		l.evicted = make(map[string]bool)
+	}
+	l.evicted[jobID] = true
+}
+
+// JobCacheEnabled reports whether the client has a job list cache.
+func (c *Client) JobCacheEnabled() bool {
+	return c.JobCache != nil
+}
+
+// ListedJob returns the job with the given ID from the cached job list. It reports
+// false when the cache is disabled or the job is not in the list, for example
+// because it was created or changed since the list was loaded; callers then fall
+// back to GetJobDetails.
+func (c *Client) ListedJob(ctx context.Context, jobID string) (*Job, bool, error) {
+	if c.JobCache == nil {
+		return nil, false, nil
+	}
+	// Jobs missing from an incomplete list are read from their details instead.
+	return c.JobCache.Lookup(ctx, jobID, func(ctx context.Context) ([]Job, error) {
+		jobs, _, err := c.GetJobs(ctx)
+		return jobs, err
+	})
+}
@@ -0,0 +1,159 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+// newJobCacheTestServer serves a job list with jobs 1 and 2 and the details of any job,
+// counting the requests per endpoint.
+func newJobCacheTestServer(t *testing.T, listStatus int) (*httptest.Server, *atomic.Int32, *atomic.Int32) {
+	t.Helper()
+
+	var listCalls, detailCalls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/jobs":
+			listCalls.Add(1)
+			w.WriteHeader(listStatus)
+			_, _ = w.Write([]byte(`{"jobs": [{"jobId": 1, "title": "Listed 1"}, {"jobId": 2, "title": "Listed 2"}], "someFailed": false}`))
+		case r.Method == http.MethodGet:
+			detailCalls.Add(1)
+			_, _ = w.Write([]byte(`{"jobDetails": {"jobId": 3, "title": "Detailed", "auth": {"enable": true}}}`))
+		default:
+			_, _ = w.Write([]byte(`{}`))
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server, &listCalls, &detailCalls
+}
+
+func TestJobListCache_SingleListRequest(t *testing.T) {
+	server, listCalls, detailCalls := newJobCacheTestServer(t, http.StatusOK)
+	c := NewClient(server.URL, "test-key")
+	c.RateLimiter = nil
+	c.JobCache = NewJobListCache()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(jobID string) {
+			defer wg.Done()
+			job, ok, err := c.ListedJob(context.Background(), jobID)
+			if err != nil || !ok {
+				t.Errorf("ListedJob(%s): expected a listed job, got %v, %v", jobID, ok, err)
+				return
+			}
+			if job.Title != "Listed "+jobID {
+				t.Errorf("ListedJob(%s): unexpected title %q", jobID, job.Title)
+			}
+		}([]string{"1", "2"}[i%2])
+	}
+	wg.Wait()
+
+	if n := listCalls.Load(); n != 1 {
+		t.Errorf("Expected 1 list request, got %d", n)
+	}
+	if n := detailCalls.Load(); n != 0 {
+		t.Errorf("Expected no detail requests, got %d", n)
+	}
+}
+
+func TestJobListCache_GetJob(t *testing.T) {
+	server, listCalls, detailCalls := newJobCacheTestServer(t, http.StatusOK)
+	c := NewClient(server.URL, "test-key")
+	c.RateLimiter = nil
+	c.JobCache = NewJobListCache()
+	ctx := context.Background()
+
+	job, err := c.GetJob(ctx, "1")
+	if err != nil || job.Title != "Listed 1" {
+		t.Fatalf("Expected job 1 from the list, got %+v, %v", job, err)
+	}
+
+	// A job that is not in the list is read from the details endpoint.
+	job, err = c.GetJob(ctx, "3")
+	if err != nil || job.Title != "Detailed" {
+		t.Fatalf("Expected job 3 from the details, got %+v, %v", job, err)
+	}
+
+	// A written job is evicted and read from the details endpoint afterwards.
+	if err := c.UpdateJob(ctx, "1", JobPatch{}); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok, _ := c.ListedJob(ctx, "1"); ok {
+		t.Error("Expected job 1 to be evicted after an update")
+	}
+	if err := c.DeleteJob(ctx, "2"); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok, _ := c.ListedJob(ctx, "2"); ok {
+		t.Error("Expected job 2 to be evicted after a delete")
+	}
+
+	if n := listCalls.Load(); n != 1 {
+		t.Errorf("Expected 1 list request, got %d", n)
+	}
+	if n := detailCalls.Load(); n != 1 {
+		t.Errorf("Expected 1 detail request, got %d", n)
+	}
+}
+
+func TestJobListCache_FailedLoadIsNotCached(t *testing.T) {
+	server, listCalls, _ := newJobCacheTestServer(t, http.StatusBadRequest)
+	c := NewClient(server.URL, "test-key")
+	c.RateLimiter = nil
+	c.JobCache = NewJobListCache()
+
+	for i := 0; i < 2; i++ {
+		if _, _, err := c.ListedJob(context.Background(), "1"); err == nil {
+			t.Fatal("Expected an error when the job list cannot be loaded")
+		}
+	}
+	if n := listCalls.Load(); n != 2 {
+		t.Errorf("Expected the failed load to be retried on the next lookup, got %d list requests", n)
+	}
+}
+
+func TestJobListCache_Disabled(t *testing.T) {
+	server, listCalls, detailCalls := newJobCacheTestServer(t, http.StatusOK)
+	c := NewClient(server.URL, "test-key")
+	c.RateLimiter = nil
+
+	if _, ok, err := c.ListedJob(context.Background(), "1"); ok || err != nil {
+		t.Errorf("Expected no listed job without a cache, got %v, %v", ok, err)
+	}
+	if _, err := c.GetJob(context.Background(), "1"); err != nil {
+		t.Fatal(err)
+	}
+	if listCalls.Load() != 0 || detailCalls.Load() != 1 {
+		t.Errorf("Expected only a detail request, got %d list and %d detail requests", listCalls.Load(), detailCalls.Load())
+	}
+}
+
+func TestJobListCache_EvictBeforeLoad(t *testing.T) {
+	server, listCalls, _ := newJobCacheTestServer(t, http.StatusOK)
+	c := NewClient(server.URL, "test-key")
+	c.RateLimiter = nil
+	c.JobCache = NewJobListCache()
+
+	// A list loaded while a write is in flight must not serve the written job.
+	c.JobCache.Evict("1")
+	if _, ok, err := c.ListedJob(context.Background(), "1"); ok || err != nil {
+		t.Errorf("Expected evicted job 1 not to be served, got %v, %v", ok, err)
+	}
+	if _, ok, _ := c.ListedJob(context.Background(), "2"); !ok {
+		t.Error("Expected job 2 to be served from the list")
+	}
+	if n := listCalls.Load(); n != 1 {
+		t.Errorf("Expected 1 list request, got %d", n)
+	}
+}
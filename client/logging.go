@@ -0,0 +1,76 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// LogSubsystem is the tflog subsystem under which API requests are logged: method,
+// path, status, latency and attempt at DEBUG, headers and bodies at TRACE. Its level
+// follows TF_LOG and TF_LOG_PROVIDER and can be overridden with
+// TF_LOG_PROVIDER_CRONJOBORG_CLIENT. Credentials are redacted before logging.
+const LogSubsystem = "client"
+
+// logContext returns ctx with the client's logging subsystem. The API key is masked
+// in all log output in case it ends up somewhere redaction does not cover.
+func (c *Client) logContext(ctx context.Context) context.Context {
+	ctx = tflog.NewSubsystem(ctx, LogSubsystem,
+		tflog.WithLevelFromEnv("TF_LOG_PROVIDER_CRONJOBORG", LogSubsystem),
+		tflog.WithRootFields(),
+	)
+	if c.APIKey != "" {
+		ctx = tflog.SubsystemMaskAllFieldValuesStrings(ctx, LogSubsystem, c.APIKey)
+		ctx = tflog.SubsystemMaskMessageStrings(ctx, LogSubsystem, c.APIKey)
+	}
+	return ctx
+}
+
+// logRequest logs a request attempt before it is sent.
+func (c *Client) logRequest(ctx context.Context, req *http.Request, payload []byte, attempt int) {
+	fields := map[string]interface{}{
+		"method":  req.Method,
+		"path":    req.URL.Path,
+		"attempt": attempt,
+		"headers": c.redactor.Header(req.Header),
+	}
+	if len(payload) > 0 {
+		fields["body"] = string(c.redactor.JSON(payload))
+	}
+	tflog.SubsystemTrace(ctx, LogSubsystem, "Sending API request", fields)
+}
+
+// logResponse logs the outcome of a request attempt. resp is nil if the request failed
+// without a response.
+func (c *Client) logResponse(ctx context.Context, req *http.Request, attempt int, start time.Time, resp *http.Response, body []byte, err error) {
+	fields := map[string]interface{}{
+		"method":      req.Method,
+		"path":        req.URL.Path,
+		"attempt":     attempt,
+		"duration_ms": time.Since(start).Milliseconds(),
+	}
+	if resp == nil {
+		fields["error"] = err.Error()
+		tflog.SubsystemDebug(ctx, LogSubsystem, "API request failed", fields)
+		return
+	}
+
+	fields["status"] = resp.StatusCode
+	if err != nil {
+		fields["error"] = err.Error()
+	}
+	tflog.SubsystemDebug(ctx, LogSubsystem, "Received API response", fields)
+
+	tflog.SubsystemTrace(ctx, LogSubsystem, "API response body", map[string]interface{}{
+		"method":  req.Method,
+		"path":    req.URL.Path,
+		"attempt": attempt,
+		"headers": c.redactor.Header(resp.Header),
+		"body":    string(c.redactor.JSON(body)),
+	})
+}
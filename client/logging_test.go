@@ -0,0 +1,81 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+)
+
+func TestClient_Logging(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(`{"jobDetails": {"jobId": 1, "auth": {"enable": true, "user": "admin", "password": "response-password"}, "extendedData": {"headers": {"X-Token": "response-token"}}}}`))
+	}))
+	defer server.Close()
+
+	c := NewClient(server.URL, "secret-api-key", WithSensitiveHeaders("X-Token"), WithRetry(RetryPolicy{MaxAttempts: 2}))
+	c.RateLimiter = nil
+
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+
+	patch := JobPatch{
+		Auth:         &JobAuth{Enable: true, User: "admin", Password: "request-password"},
+		ExtendedData: &JobExtendedData{Headers: map[string]string{"X-Token": "request-token", "Accept": "text/plain"}},
+	}
+	if err := c.UpdateJob(ctx, "1", patch); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.GetJobDetails(ctx, "1"); err != nil {
+		t.Fatal(err)
+	}
+
+	logs := output.String()
+	for _, secret := range []string{"secret-api-key", "request-password", "response-password", "request-token", "response-token"} {
+		if strings.Contains(logs, secret) {
+			t.Errorf("Expected %q to be redacted from the logs:\n%s", secret, logs)
+		}
+	}
+
+	entries, err := tflogtest.MultilineJSONDecode(&output)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var responses, retries, bodies int
+	for _, entry := range entries {
+		switch entry["@message"] {
+		case "Received API response":
+			responses++
+			if entry["@level"] != "debug" || entry["method"] == nil || entry["path"] == nil || entry["status"] == nil || entry["attempt"] == nil || entry["duration_ms"] == nil {
+				t.Errorf("Expected a debug entry with method, path, status, attempt and duration, got %v", entry)
+			}
+		case "Retrying API request":
+			retries++
+		case "Sending API request", "API response body":
+			bodies++
+			if entry["@level"] != "trace" {
+				t.Errorf("Expected bodies to be logged at trace level, got %v", entry)
+			}
+		}
+	}
+	if responses != 3 || retries != 1 || bodies != 6 {
+		t.Errorf("Expected 3 responses, 1 retry and 6 body entries, got %d, %d and %d:\n%s", responses, retries, bodies, logs)
+	}
+	if !strings.Contains(logs, "text/plain") || !strings.Contains(logs, `\"user\":\"admin\"`) {
+		t.Errorf("Expected non-sensitive values to be logged:\n%s", logs)
+	}
+}
@@ -0,0 +1,142 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"net/http"
+	"net/url"
+	"time"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// DefaultTimeout is the default time limit for a single HTTP request, including reading the response.
+	DefaultTimeout = 60 * time.Second
+	// DefaultUserAgent is the User-Agent sent when none is configured.
+	DefaultUserAgent = "terraform-provider-cronjoborg"
+)
+
+// Option configures a Client created by NewClient.
+type Option func(*clientOptions)
+
+// clientOptions collects the options before the client and its transport are built.
+type clientOptions struct {
+	httpClient         *http.Client
+	timeout            *time.Duration
+	userAgent          string
+	proxy              *url.URL
+	rootCAs            *x509.CertPool
+	insecureSkipVerify bool
+	retry              *RetryPolicy
+	sensitiveHeaders   []string
+	tracerProvider     trace.TracerProvider
+}
+
+// WithHTTPClient makes the client send requests with a copy of hc. It takes precedence
+// over WithProxy, WithRootCAs and WithInsecureSkipVerify, which only configure the
+// transport of the default HTTP client. hc keeps its timeout unless WithTimeout is given.
+func WithHTTPClient(hc *http.Client) Option {
+	return func(o *clientOptions) {
+		o.httpClient = hc
+	}
+}
+
+// WithTimeout sets the time limit for a single HTTP request attempt. Zero disables the limit.
+func WithTimeout(timeout time.Duration) Option {
+	return func(o *clientOptions) {
+		o.timeout = &timeout
+	}
+}
+
+// WithUserAgent sets the User-Agent header sent with every request.
+func WithUserAgent(userAgent string) Option {
+	return func(o *clientOptions) {
+		o.userAgent = userAgent
+	}
+}
+
+// WithProxy sends requests through the given HTTP(S) proxy instead of the proxy
+// selected by the HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables.
+func WithProxy(proxy *url.URL) Option {
+	return func(o *clientOptions) {
+		o.proxy = proxy
+	}
+}
+
+// WithRootCAs verifies the API's TLS certificate against pool instead of the system roots.
+func WithRootCAs(pool *x509.CertPool) Option {
+	return func(o *clientOptions) {
+		o.rootCAs = pool
+	}
+}
+
+// WithInsecureSkipVerify disables verification of the API's TLS certificate.
+// It should only be used for testing.
+func WithInsecureSkipVerify(skip bool) Option {
+	return func(o *clientOptions) {
+		o.insecureSkipVerify = skip
+	}
+}
+
+// WithRetry sets the retry policy for transient failures.
+func WithRetry(policy RetryPolicy) Option {
+	return func(o *clientOptions) {
+		o.retry = &policy
+	}
+}
+
+// WithSensitiveHeaders redacts the values of the given job request headers, in addition
+// to redact.DefaultSensitiveHeaders, when requests and responses are logged.
+func WithSensitiveHeaders(names ...string) Option {
+	return func(o *clientOptions) {
+		o.sensitiveHeaders = append(o.sensitiveHeaders, names...)
+	}
+}
+
+// WithTracerProvider creates the spans of API calls with tp instead of the global
+// TracerProvider, which does not record anything unless the program configures it.
+func WithTracerProvider(tp trace.TracerProvider) Option {
+	return func(o *clientOptions) {
+		o.tracerProvider = tp
+	}
+}
+
+// newHTTPClient returns the HTTP client described by the options.
+func (o *clientOptions) newHTTPClient() *http.Client {
+	if o.httpClient != nil {
+		hc := *o.httpClient
+		if o.timeout != nil {
+			hc.Timeout = *o.timeout
+		}
+		return &hc
+	}
+
+	timeout := DefaultTimeout
+	if o.timeout != nil {
+		timeout = *o.timeout
+	}
+
+	transport := &http.Transport{Proxy: http.ProxyFromEnvironment}
+	if defaultTransport, ok := http.DefaultTransport.(*http.Transport); ok {
+		transport = defaultTransport.Clone()
+	}
+	if o.proxy != nil {
+		transport.Proxy = http.ProxyURL(o.proxy)
+	}
+	if o.rootCAs != nil || o.insecureSkipVerify {
+		transport.TLSClientConfig = &tls.Config{
+			MinVersion:         tls.VersionTLS12,
+			RootCAs:            o.rootCAs,
+			InsecureSkipVerify: o.insecureSkipVerify, //nolint:gosec // Explicitly requested by the user
+		}
+	}
+
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: transport,
+	}
+}
@@ -0,0 +1,123 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"crypto/x509"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestNewClient_Options(t *testing.T) {
+	policy := RetryPolicy{MaxAttempts: 1}
+	client := NewClient("https://api.example.com", "test-key",
+		WithTimeout(5*time.Second),
+		WithUserAgent("test-agent/1.0"),
+		WithRetry(policy),
+	)
+
+	if client.HTTPClient.Timeout != 5*time.Second {
+		t.Errorf("Expected timeout 5s, got %v", client.HTTPClient.Timeout)
+	}
+	if client.UserAgent != "test-agent/1.0" {
+		t.Errorf("Expected UserAgent test-agent/1.0, got %s", client.UserAgent)
+	}
+	if client.Retry != policy {
+		t.Errorf("Expected retry policy %+v, got %+v", policy, client.Retry)
+	}
+}
+
+func TestNewClient_WithHTTPClient(t *testing.T) {
+	hc := &http.Client{Timeout: time.Second}
+
+	client := NewClient("https://api.example.com", "test-key", WithHTTPClient(hc))
+	if client.HTTPClient.Timeout != time.Second {
+		t.Errorf("Expected the HTTP client's own timeout, got %v", client.HTTPClient.Timeout)
+	}
+
+	client = NewClient("https://api.example.com", "test-key", WithHTTPClient(hc), WithTimeout(time.Minute))
+	if client.HTTPClient.Timeout != time.Minute {
+		t.Errorf("Expected timeout 1m, got %v", client.HTTPClient.Timeout)
+	}
+	if hc.Timeout != time.Second {
+		t.Error("Expected the given HTTP client not to be modified")
+	}
+}
+
+func TestNewClient_UserAgentHeader(t *testing.T) {
+	var userAgent string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userAgent = r.Header.Get("User-Agent")
+		_, _ = w.Write([]byte(`{"jobs": [], "someFailed": false}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key", WithUserAgent("test-agent/1.0"))
+	client.RateLimiter = nil
+	if _, _, err := client.GetJobs(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if userAgent != "test-agent/1.0" {
+		t.Errorf("Expected User-Agent test-agent/1.0, got %q", userAgent)
+	}
+}
+
+func TestNewClient_WithProxy(t *testing.T) {
+	var proxied string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied = r.URL.String()
+		_, _ = w.Write([]byte(`{"jobs": [], "someFailed": false}`))
+	}))
+	defer proxy.Close()
+
+	proxyURL, err := url.Parse(proxy.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client := NewClient("http://api.example.invalid", "test-key", WithProxy(proxyURL), WithRetry(RetryPolicy{MaxAttempts: 1}))
+	client.RateLimiter = nil
+	if _, _, err := client.GetJobs(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if proxied != "http://api.example.invalid/jobs" {
+		t.Errorf("Expected the request to go through the proxy, got %q", proxied)
+	}
+}
+
+func TestNewClient_TLS(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"jobs": [], "someFailed": false}`))
+	}))
+	defer server.Close()
+
+	pool := x509.NewCertPool()
+	pool.AddCert(server.Certificate())
+
+	tests := []struct {
+		name    string
+		opts    []Option
+		wantErr bool
+	}{
+		{name: "untrusted certificate", wantErr: true},
+		{name: "root CAs", opts: []Option{WithRootCAs(pool)}},
+		{name: "insecure skip verify", opts: []Option{WithInsecureSkipVerify(true)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := append([]Option{WithRetry(RetryPolicy{MaxAttempts: 1})}, tt.opts...)
+			client := NewClient(server.URL, "test-key", opts...)
+			client.RateLimiter = nil
+			_, _, err := client.GetJobs(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetJobs() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,279 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// ErrBudgetExhausted is returned before sending a request that would exceed the configured request budget.
+var ErrBudgetExhausted = errors.New("API request budget exhausted")
+
+// DefaultBudgetWarningThreshold is the default fraction of the daily budget after which a warning is reported.
+const DefaultBudgetWarningThreshold = 0.8
+
+const (
+	// quotaLockTimeout is how long Acquire waits for another process to release the state file.
+	quotaLockTimeout = 10 * time.Second
+	// quotaLockStale is the age after which a lock file is assumed to be left behind by a crashed process.
+	quotaLockStale = time.Minute
+	// quotaLockRetry is the interval in which a held lock is checked again.
+	quotaLockRetry = 10 * time.Millisecond
+)
+
+// QuotaBudget configures client-side accounting of the daily API request quota.
+type QuotaBudget struct {
+	// DailyLimit is the maximum number of requests per UTC day (0 = unlimited).
+	DailyLimit int
+	// MaxPerRun is the maximum number of requests made by this client instance (0 = unlimited).
+	MaxPerRun int
+	// WarningThreshold is the fraction of DailyLimit after which a warning is reported once per day.
+	WarningThreshold float64
+	// StateFile, if set, persists the daily request count per API key so that it
+	// survives across Terraform runs. Without it, only requests of the current run are counted.
+	// Concurrent runs serialize their updates through a lock file next to it.
+	StateFile string
+}
+
+// BudgetWarning reports that the daily request count crossed QuotaBudget.WarningThreshold.
+type BudgetWarning struct {
+	Requests   int
+	DailyLimit int
+	// Date is the UTC day the requests were counted on, e.g. "2025-01-31".
+	Date string
+}
+
+// String returns the warning message.
+func (w BudgetWarning) String() string {
+	return fmt.Sprintf("cron-job.org API request budget: %d of %d daily requests used for %s (UTC)", w.Requests, w.DailyLimit, w.Date)
+}
+
+// quotaState is the on-disk format of QuotaBudget.StateFile.
+type quotaState struct {
+	Keys map[string]quotaDay `json:"keys"`
+}
+
+// quotaDay holds the request count of one API key on one UTC day.
+type quotaDay struct {
+	Date     string `json:"date"`
+	Requests int    `json:"requests"`
+}
+
+// QuotaTracker counts API requests and refuses requests that would exceed the configured budget.
+// It is safe for concurrent use.
+type QuotaTracker struct {
+	mu      sync.Mutex
+	budget  QuotaBudget
+	keyID   string
+	date    string
+	daily   int
+	run     int
+	warned  bool
+	pending *BudgetWarning
+	now     func() time.Time
+}
+
+// NewQuotaTracker creates a tracker for the given API key. The key itself is never
+// persisted; the state file only contains a hash of it.
+func NewQuotaTracker(apiKey string, budget QuotaBudget) *QuotaTracker {
+	sum := sha256.Sum256([]byte(apiKey))
+	return &QuotaTracker{
+		budget: budget,
+		keyID:  hex.EncodeToString(sum[:8]),
+		now:    time.Now,
+	}
+}
+
+// Usage returns the number of requests made in this run and on the current UTC day.
+func (q *QuotaTracker) Usage() (run, daily int) {
+	if q == nil {
+		return 0, 0
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.run, q.daily
+}
+
+// TakeWarning returns the warning of a crossed warning threshold that has not been
+// taken yet.
+func (q *QuotaTracker) TakeWarning() (BudgetWarning, bool) {
+	if q == nil {
+		return BudgetWarning{}, false
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.pending == nil {
+		return BudgetWarning{}, false
+	}
+	warning := *q.pending
+	q.pending = nil
+	return warning, true
+}
+
+// Acquire accounts for one request about to be sent. It returns an error wrapping
+// ErrBudgetExhausted if the request would exceed the budget. Crossing the warning
+// threshold is logged to the client log subsystem of ctx and kept for TakeWarning.
+func (q *QuotaTracker) Acquire(ctx context.Context) error {
+	if q == nil {
+		return nil
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	date := q.now().UTC().Format(time.DateOnly)
+	if date != q.date {
+		q.date = date
+		q.daily = 0
+		q.warned = false
+	}
+
+	// Another Terraform process using the same state file may have made requests in the meantime.
+	if q.budget.StateFile != "" {
+		unlock, err := q.lock()
+		if err != nil {
+			return err
+		}
+		defer unlock()
+
+		state, err := q.load()
+		if err != nil {
+			return err
+		}
+		if day, ok := state.Keys[q.keyID]; ok && day.Date == date && day.Requests > q.daily {
+			q.daily = day.Requests
+		}
+	}
+
+	if q.budget.MaxPerRun > 0 && q.run >= q.budget.MaxPerRun {
+		return fmt.Errorf("%w: all %d requests allowed per run have been used; raise max_requests_per_run or split the run", ErrBudgetExhausted, q.budget.MaxPerRun)
+	}
+	if q.budget.DailyLimit > 0 && q.daily >= q.budget.DailyLimit {
+		return fmt.Errorf("%w: all %d requests of the daily budget for %s (UTC) have been used; raise daily_request_budget or wait until the budget resets at midnight UTC", ErrBudgetExhausted, q.budget.DailyLimit, date)
+	}
+
+	q.run++
+	q.daily++
+
+	if q.budget.StateFile != "" {
+		if err := q.save(date); err != nil {
+			return err
+		}
+	}
+
+	if q.budget.DailyLimit > 0 && q.budget.WarningThreshold > 0 && !q.warned {
+		threshold := int(math.Ceil(q.budget.WarningThreshold * float64(q.budget.DailyLimit)))
+		if q.daily >= threshold {
+			q.warned = true
+			q.pending = &BudgetWarning{Requests: q.daily, DailyLimit: q.budget.DailyLimit, Date: date}
+			tflog.SubsystemWarn(ctx, LogSubsystem, q.pending.String(), map[string]interface{}{
+				"requests":     q.daily,
+				"daily_budget": q.budget.DailyLimit,
+			})
+		}
+	}
+
+	return nil
+}
+
+// lock creates a lock file next to the state file, waiting while another process holds
+// it. It returns a function that releases the lock.
+func (q *QuotaTracker) lock() (func(), error) {
+	path := q.budget.StateFile + ".lock"
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create request budget state directory: %w", err)
+	}
+
+	deadline := time.Now().Add(quotaLockTimeout)
+	for {
+		f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+		if err == nil {
+			f.Close()
+			return func() { os.Remove(path) }, nil
+		}
+		if !errors.Is(err, os.ErrExist) {
+			return nil, fmt.Errorf("failed to lock request budget state file: %w", err)
+		}
+
+		// Break locks left behind by crashed processes.
+		if info, err := os.Stat(path); err == nil && time.Since(info.ModTime()) > quotaLockStale {
+			os.Remove(path)
+			continue
+		}
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("timed out waiting for the request budget state file lock %s; delete it if no other Terraform run is using the state file", path)
+		}
+		time.Sleep(quotaLockRetry)
+	}
+}
+
+// load reads the state file. A missing file is treated as empty state.
+func (q *QuotaTracker) load() (*quotaState, error) {
+	state := &quotaState{Keys: make(map[string]quotaDay)}
+
+	data, err := os.ReadFile(q.budget.StateFile)
+	if errors.Is(err, os.ErrNotExist) {
+		return state, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read request budget state file: %w", err)
+	}
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil, fmt.Errorf("failed to parse request budget state file %s: %w", q.budget.StateFile, err)
+	}
+	if state.Keys == nil {
+		state.Keys = make(map[string]quotaDay)
+	}
+
+	return state, nil
+}
+
+// save writes the current daily count to the state file, replacing it atomically.
+func (q *QuotaTracker) save(date string) error {
+	state, err := q.load()
+	if err != nil {
+		return err
+	}
+	state.Keys[q.keyID] = quotaDay{Date: date, Requests: q.daily}
+
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode request budget state: %w", err)
+	}
+
+	dir := filepath.Dir(q.budget.StateFile)
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return fmt.Errorf("failed to create request budget state directory: %w", err)
+	}
+	tmp, err := os.CreateTemp(dir, filepath.Base(q.budget.StateFile)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to write request budget state file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write request budget state file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write request budget state file: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), q.budget.StateFile); err != nil {
+		return fmt.Errorf("failed to write request budget state file: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,229 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+)
+
+func TestQuotaTracker_MaxPerRun(t *testing.T) {
+	q := NewQuotaTracker("test-key", QuotaBudget{MaxPerRun: 2})
+
+	for i := 0; i < 2; i++ {
+		if err := q.Acquire(context.Background()); err != nil {
+			t.Fatalf("request %d: expected no error, got %v", i+1, err)
+		}
+	}
+
+	err := q.Acquire(context.Background())
+	if !errors.Is(err, ErrBudgetExhausted) {
+		t.Fatalf("Expected ErrBudgetExhausted, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "max_requests_per_run") {
+		t.Errorf("Expected error to mention max_requests_per_run, got %q", err.Error())
+	}
+
+	if run, daily := q.Usage(); run != 2 || daily != 2 {
+		t.Errorf("Expected usage 2/2, got %d/%d", run, daily)
+	}
+}
+
+func TestQuotaTracker_DailyLimitResetsAtMidnightUTC(t *testing.T) {
+	now := time.Date(2024, 3, 1, 23, 59, 0, 0, time.UTC)
+	q := NewQuotaTracker("test-key", QuotaBudget{DailyLimit: 1})
+	q.now = func() time.Time { return now }
+
+	if err := q.Acquire(context.Background()); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if err := q.Acquire(context.Background()); !errors.Is(err, ErrBudgetExhausted) {
+		t.Fatalf("Expected ErrBudgetExhausted, got %v", err)
+	}
+
+	now = now.Add(2 * time.Minute)
+	if err := q.Acquire(context.Background()); err != nil {
+		t.Fatalf("Expected budget to reset on a new UTC day, got %v", err)
+	}
+}
+
+func TestQuotaTracker_WarningThreshold(t *testing.T) {
+	var output bytes.Buffer
+	ctx := tflog.NewSubsystem(tflogtest.RootLogger(context.Background(), &output), LogSubsystem)
+	q := NewQuotaTracker("test-key", QuotaBudget{DailyLimit: 10, WarningThreshold: 0.5})
+
+	warnings := func() []map[string]interface{} {
+		t.Helper()
+		entries, err := tflogtest.MultilineJSONDecode(bytes.NewReader(output.Bytes()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return entries
+	}
+
+	for i := 0; i < 4; i++ {
+		_ = q.Acquire(ctx)
+	}
+	if entries := warnings(); len(entries) != 0 {
+		t.Fatalf("Expected no warning below the threshold, got %v", entries)
+	}
+	if warning, ok := q.TakeWarning(); ok {
+		t.Fatalf("Expected no warning below the threshold, got %v", warning)
+	}
+
+	for i := 0; i < 3; i++ {
+		_ = q.Acquire(ctx)
+	}
+	entries := warnings()
+	if len(entries) != 1 {
+		t.Fatalf("Expected exactly one warning after crossing the threshold, got %v", entries)
+	}
+	if entries[0]["@level"] != "warn" || entries[0]["@module"] != "provider."+LogSubsystem {
+		t.Errorf("Expected a warning in the %s log subsystem, got %v", LogSubsystem, entries[0])
+	}
+	if message, _ := entries[0]["@message"].(string); !strings.Contains(message, "5 of 10") {
+		t.Errorf("Expected warning to report 5 of 10 requests, got %q", message)
+	}
+
+	// The warning is taken once, for the provider to report it as a diagnostic.
+	if warning, ok := q.TakeWarning(); !ok || warning.Requests != 5 || warning.DailyLimit != 10 {
+		t.Errorf("Expected a warning for 5 of 10 requests, got %v (%v)", warning, ok)
+	}
+	if warning, ok := q.TakeWarning(); ok {
+		t.Errorf("Expected the warning to be taken only once, got %v", warning)
+	}
+}
+
+func TestQuotaTracker_StateFilePersistence(t *testing.T) {
+	stateFile := filepath.Join(t.TempDir(), "budget", "state.json")
+	budget := QuotaBudget{DailyLimit: 3, StateFile: stateFile}
+
+	first := NewQuotaTracker("test-key", budget)
+	for i := 0; i < 2; i++ {
+		if err := first.Acquire(context.Background()); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+	}
+
+	data, err := os.ReadFile(stateFile)
+	if err != nil {
+		t.Fatalf("Expected state file to be written, got %v", err)
+	}
+	if strings.Contains(string(data), "test-key") {
+		t.Error("State file must not contain the API key")
+	}
+
+	// A second run with the same key continues counting where the first one stopped.
+	second := NewQuotaTracker("test-key", budget)
+	if err := second.Acquire(context.Background()); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if err := second.Acquire(context.Background()); !errors.Is(err, ErrBudgetExhausted) {
+		t.Fatalf("Expected ErrBudgetExhausted, got %v", err)
+	}
+
+	// Other API keys have their own budget.
+	other := NewQuotaTracker("other-key", budget)
+	if err := other.Acquire(context.Background()); err != nil {
+		t.Fatalf("Expected no error for a different key, got %v", err)
+	}
+}
+
+func TestQuotaTracker_StateFileConcurrentRuns(t *testing.T) {
+	stateFile := filepath.Join(t.TempDir(), "state.json")
+	budget := QuotaBudget{DailyLimit: 1000, StateFile: stateFile}
+
+	// Each tracker stands for a separate Terraform process sharing the state file.
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		q := NewQuotaTracker("test-key", budget)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 25; j++ {
+				if err := q.Acquire(context.Background()); err != nil {
+					t.Errorf("Expected no error, got %v", err)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	// A later run picks up the count of all earlier ones.
+	q := NewQuotaTracker("test-key", budget)
+	if err := q.Acquire(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if _, daily := q.Usage(); daily != 101 {
+		t.Errorf("Expected all 100 concurrent requests to be counted, got %d", daily-1)
+	}
+	if _, err := os.Stat(stateFile + ".lock"); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("Expected the lock file to be removed, got %v", err)
+	}
+}
+
+func TestQuotaTracker_StaleLock(t *testing.T) {
+	stateFile := filepath.Join(t.TempDir(), "state.json")
+	lockFile := stateFile + ".lock"
+	if err := os.WriteFile(lockFile, nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	old := time.Now().Add(-2 * quotaLockStale)
+	if err := os.Chtimes(lockFile, old, old); err != nil {
+		t.Fatal(err)
+	}
+
+	q := NewQuotaTracker("test-key", QuotaBudget{DailyLimit: 10, StateFile: stateFile})
+	if err := q.Acquire(context.Background()); err != nil {
+		t.Fatalf("Expected a stale lock to be broken, got %v", err)
+	}
+}
+
+func TestQuotaTracker_CorruptStateFile(t *testing.T) {
+	stateFile := filepath.Join(t.TempDir(), "state.json")
+	if err := os.WriteFile(stateFile, []byte("not json"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	q := NewQuotaTracker("test-key", QuotaBudget{DailyLimit: 10, StateFile: stateFile})
+	if err := q.Acquire(context.Background()); err == nil {
+		t.Fatal("Expected an error for a corrupt state file")
+	}
+}
+
+func TestClient_QuotaStopsRequests(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key")
+	client.Quota = NewQuotaTracker("test-key", QuotaBudget{MaxPerRun: 1})
+
+	if err := client.DeleteJob(context.Background(), "1"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if err := client.DeleteJob(context.Background(), "2"); !errors.Is(err, ErrBudgetExhausted) {
+		t.Fatalf("Expected ErrBudgetExhausted, got %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("Expected the second request not to be sent, got %d requests", calls)
+	}
+}
@@ -0,0 +1,162 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// EndpointClass groups API endpoints that share a documented rate limit.
+type EndpointClass string
+
+const (
+	// EndpointClassDefault covers all endpoints limited to 5 requests per second.
+	EndpointClassDefault EndpointClass = "default"
+	// EndpointClassCreate covers job creation (PUT /jobs), limited to 1 request per second and 5 requests per minute.
+	EndpointClassCreate EndpointClass = "create"
+)
+
+// RateLimit allows Requests requests per Period, with bursts of up to Requests requests.
+type RateLimit struct {
+	Requests int
+	Period   time.Duration
+}
+
+// DefaultRateLimits returns the per-endpoint limits documented by cron-job.org.
+func DefaultRateLimits() map[EndpointClass][]RateLimit {
+	return map[EndpointClass][]RateLimit{
+		EndpointClassDefault: {
+			{Requests: 5, Period: time.Second},
+		},
+		EndpointClassCreate: {
+			{Requests: 1, Period: time.Second},
+			{Requests: 5, Period: time.Minute},
+		},
+	}
+}
+
+// endpointClass returns the rate limit class of an API call.
+func endpointClass(method, path string) EndpointClass {
+	if method == http.MethodPut && path == "/jobs" {
+		return EndpointClassCreate
+	}
+	return EndpointClassDefault
+}
+
+// tokenBucket is a single token bucket. Tokens may go negative to represent
+// reservations made by callers that are still waiting.
+type tokenBucket struct {
+	capacity float64
+	perToken time.Duration
+	tokens   float64
+	last     time.Time
+}
+
+func newTokenBucket(limit RateLimit, now time.Time) *tokenBucket {
+	return &tokenBucket{
+		capacity: float64(limit.Requests),
+		perToken: limit.Period / time.Duration(limit.Requests),
+		tokens:   float64(limit.Requests),
+		last:     now,
+	}
+}
+
+func (b *tokenBucket) refill(now time.Time) {
+	if elapsed := now.Sub(b.last); elapsed > 0 {
+		b.tokens += float64(elapsed) / float64(b.perToken)
+		if b.tokens > b.capacity {
+			b.tokens = b.capacity
+		}
+		b.last = now
+	}
+}
+
+// delay returns how long a caller has to wait until a token is available.
+func (b *tokenBucket) delay() time.Duration {
+	if b.tokens >= 1 {
+		return 0
+	}
+	return time.Duration((1 - b.tokens) * float64(b.perToken))
+}
+
+// RateLimiter is a concurrency-safe client-side rate limiter keyed by endpoint class.
+// Callers block until all buckets of their class have a token available instead of
+// running into 429 responses.
+type RateLimiter struct {
+	mu      sync.Mutex
+	buckets map[EndpointClass][]*tokenBucket
+	now     func() time.Time
+}
+
+// NewRateLimiter creates a rate limiter enforcing the given limits. Limits with a
+// non-positive request count or period are ignored, and classes without limits
+// are not throttled.
+func NewRateLimiter(limits map[EndpointClass][]RateLimit) *RateLimiter {
+	l := &RateLimiter{
+		buckets: make(map[EndpointClass][]*tokenBucket),
+		now:     time.Now,
+	}
+
+	now := l.now()
+	for class, classLimits := range limits {
+		for _, limit := range classLimits {
+			if limit.Requests <= 0 || limit.Period <= 0 {
+				continue
+			}
+			l.buckets[class] = append(l.buckets[class], newTokenBucket(limit, now))
+		}
+	}
+
+	return l
+}
+
+// reserve takes a token from every bucket of the class and returns how long the
+// caller has to wait before the reservation becomes valid.
+func (l *RateLimiter) reserve(class EndpointClass) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	var wait time.Duration
+	for _, b := range l.buckets[class] {
+		b.refill(now)
+		if d := b.delay(); d > wait {
+			wait = d
+		}
+	}
+	for _, b := range l.buckets[class] {
+		b.tokens--
+	}
+
+	return wait
+}
+
+// cancel returns a token taken by reserve to every bucket of the class.
+func (l *RateLimiter) cancel(class EndpointClass) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for _, b := range l.buckets[class] {
+		b.tokens++
+	}
+}
+
+// Wait blocks until a request of the given class may be sent, or until the context is done.
+// It returns the time spent waiting.
+func (l *RateLimiter) Wait(ctx context.Context, class EndpointClass) (time.Duration, error) {
+	if l == nil {
+		return 0, nil
+	}
+
+	wait := l.reserve(class)
+	if err := sleepContext(ctx, wait); err != nil {
+		l.cancel(class)
+		return 0, err
+	}
+
+	return wait, nil
+}
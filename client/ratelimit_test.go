@@ -0,0 +1,156 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestEndpointClass(t *testing.T) {
+	testCases := []struct {
+		method   string
+		path     string
+		expected EndpointClass
+	}{
+		{http.MethodPut, "/jobs", EndpointClassCreate},
+		{http.MethodGet, "/jobs", EndpointClassDefault},
+		{http.MethodPatch, "/jobs/123", EndpointClassDefault},
+		{http.MethodDelete, "/jobs/123", EndpointClassDefault},
+		{http.MethodGet, "/jobs/123/history", EndpointClassDefault},
+	}
+
+	for _, tc := range testCases {
+		if got := endpointClass(tc.method, tc.path); got != tc.expected {
+			t.Errorf("endpointClass(%s, %s): expected %s, got %s", tc.method, tc.path, tc.expected, got)
+		}
+	}
+}
+
+func TestRateLimiter_CreateReservations(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	l := NewRateLimiter(DefaultRateLimits())
+	l.now = func() time.Time { return now }
+	for _, buckets := range l.buckets {
+		for _, b := range buckets {
+			b.last = now
+		}
+	}
+
+	// Five creates are paced by the 1/s bucket, the sixth by the 5/min bucket.
+	expected := []time.Duration{0, time.Second, 2 * time.Second, 3 * time.Second, 4 * time.Second, 12 * time.Second}
+	for i, want := range expected {
+		if got := l.reserve(EndpointClassCreate); got != want {
+			t.Errorf("reservation %d: expected wait %v, got %v", i+1, want, got)
+		}
+	}
+
+	// Other endpoints are not affected by the create limits.
+	if got := l.reserve(EndpointClassDefault); got != 0 {
+		t.Errorf("Expected default class to be unthrottled, got wait %v", got)
+	}
+}
+
+func TestRateLimiter_Refill(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	l := NewRateLimiter(map[EndpointClass][]RateLimit{
+		EndpointClassDefault: {{Requests: 2, Period: time.Second}},
+	})
+	l.now = func() time.Time { return now }
+	for _, b := range l.buckets[EndpointClassDefault] {
+		b.last = now
+	}
+
+	l.reserve(EndpointClassDefault)
+	l.reserve(EndpointClassDefault)
+	if got := l.reserve(EndpointClassDefault); got != 500*time.Millisecond {
+		t.Fatalf("Expected wait of 500ms when the bucket is empty, got %v", got)
+	}
+
+	now = now.Add(2 * time.Second)
+	if got := l.reserve(EndpointClassDefault); got != 0 {
+		t.Errorf("Expected no wait after the bucket refilled, got %v", got)
+	}
+}
+
+func TestRateLimiter_ConcurrentWait(t *testing.T) {
+	l := NewRateLimiter(map[EndpointClass][]RateLimit{
+		EndpointClassDefault: {{Requests: 2, Period: 100 * time.Millisecond}},
+	})
+
+	start := time.Now()
+	var wg sync.WaitGroup
+	for i := 0; i < 6; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := l.Wait(context.Background(), EndpointClassDefault); err != nil {
+				t.Errorf("Expected no error, got %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	// Two requests pass immediately, the remaining four need 50ms each.
+	if elapsed := time.Since(start); elapsed < 180*time.Millisecond {
+		t.Errorf("Expected six requests to take at least 200ms, took %v", elapsed)
+	}
+}
+
+func TestRateLimiter_WaitCanceled(t *testing.T) {
+	l := NewRateLimiter(map[EndpointClass][]RateLimit{
+		EndpointClassDefault: {{Requests: 1, Period: time.Hour}},
+	})
+
+	if _, err := l.Wait(context.Background(), EndpointClassDefault); err != nil {
+		t.Fatalf("Expected first request to pass, got %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	if _, err := l.Wait(ctx, EndpointClassDefault); err == nil {
+		t.Fatal("Expected an error when the context expires while waiting")
+	}
+
+	// The canceled reservation must have been returned to the bucket.
+	if tokens := l.buckets[EndpointClassDefault][0].tokens; tokens < -0.01 || tokens > 0.01 {
+		t.Errorf("Expected canceled reservation to be refunded, bucket has %v tokens", tokens)
+	}
+}
+
+func TestRateLimiter_Nil(t *testing.T) {
+	var l *RateLimiter
+	if wait, err := l.Wait(context.Background(), EndpointClassCreate); err != nil || wait != 0 {
+		t.Errorf("Expected nil limiter to never wait, got %v, %v", wait, err)
+	}
+}
+
+func TestClient_RateLimitedCreate(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"jobId": 1}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key")
+	client.RateLimiter = NewRateLimiter(map[EndpointClass][]RateLimit{
+		EndpointClassCreate: {{Requests: 1, Period: 100 * time.Millisecond}},
+	})
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		if _, err := client.CreateJob(context.Background(), JobCreate{URL: "https://example.com"}); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+	}
+
+	if elapsed := time.Since(start); elapsed < 190*time.Millisecond {
+		t.Errorf("Expected three creates to take at least 200ms, took %v", elapsed)
+	}
+}
@@ -0,0 +1,143 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultRetryMaxAttempts is the default number of attempts per request, including the first one.
+	DefaultRetryMaxAttempts = 4
+	// DefaultRetryMinWait is the default base delay before the first retry.
+	DefaultRetryMinWait = 1 * time.Second
+	// DefaultRetryMaxWait is the default upper bound for a single delay between attempts.
+	DefaultRetryMaxWait = 30 * time.Second
+)
+
+// RetryPolicy controls how requests that failed with a transient error are retried.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts per request, including the first one.
+	// A value of 1 or less disables retries.
+	MaxAttempts int
+	// MinWait is the base delay used for exponential backoff.
+	MinWait time.Duration
+	// MaxWait caps a single delay between attempts. A Retry-After header asking
+	// for a longer delay aborts the retry loop instead of being shortened.
+	MaxWait time.Duration
+}
+
+// DefaultRetryPolicy returns the retry policy used by NewClient.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: DefaultRetryMaxAttempts,
+		MinWait:     DefaultRetryMinWait,
+		MaxWait:     DefaultRetryMaxWait,
+	}
+}
+
+// isIdempotentMethod reports whether a request with the given method can be
+// safely repeated after an ambiguous failure. PUT /jobs creates a new job on
+// every call and is therefore not idempotent in this API.
+func isIdempotentMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPatch, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+// retryDelay decides whether the failed attempt should be retried and how long to wait before
+// the next one. attempt is the 1-based number of the attempt that just failed. The caller
+// must not retry once its context is done; a timeout of the HTTP client itself is treated
+// like any other transport error. Requests that match ErrNotSent are retried regardless
+// of their method, as the API cannot have acted on them.
+func (p RetryPolicy) retryDelay(method string, attempt int, err error) (time.Duration, bool) {
+	if attempt >= p.MaxAttempts {
+		return 0, false
+	}
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		// Transport errors are ambiguous: the server may or may not have processed the request.
+		if !isIdempotentMethod(method) && !errors.Is(err, ErrNotSent) {
+			return 0, false
+		}
+		return p.backoff(attempt), true
+	}
+
+	if !apiErr.Retryable() {
+		return 0, false
+	}
+
+	if apiErr.retryAfter > 0 {
+		if apiErr.retryAfter > p.MaxWait {
+			return 0, false
+		}
+		return apiErr.retryAfter, true
+	}
+	return p.backoff(attempt), true
+}
+
+// backoff returns an exponentially growing delay with jitter, capped at MaxWait.
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	wait := p.MinWait
+	for i := 1; i < attempt && wait < p.MaxWait; i++ {
+		wait *= 2
+	}
+	if wait > p.MaxWait {
+		wait = p.MaxWait
+	}
+	if wait <= 0 {
+		return 0
+	}
+
+	// Equal jitter: keep half of the delay and randomize the other half so
+	// that parallel Terraform operations do not retry in lockstep.
+	half := wait / 2
+	return half + rand.N(wait-half+1) //nolint:gosec // Jitter does not need a cryptographic source
+}
+
+// parseRetryAfter parses a Retry-After header value given either in seconds or as an HTTP date.
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0
+		}
+		return time.Duration(seconds) * time.Second
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		if wait := date.Sub(now); wait > 0 {
+			return wait
+		}
+	}
+	return 0
+}
+
+// sleepContext waits for the given duration or until the context is done.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,244 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+)
+
+// newTestRetryClient returns a client whose retry delays are short enough for unit tests.
+func newTestRetryClient(baseURL string, maxAttempts int) *Client {
+	c := NewClient(baseURL, "test-key")
+	c.Retry = RetryPolicy{
+		MaxAttempts: maxAttempts,
+		MinWait:     time.Millisecond,
+		MaxWait:     10 * time.Millisecond,
+	}
+	c.RateLimiter = nil
+	return c
+}
+
+func TestRetry_TransientServerErrorOnGet(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"jobs": [], "someFailed": false}`))
+	}))
+	defer server.Close()
+
+	client := newTestRetryClient(server.URL, 4)
+
+	if _, _, err := client.GetJobs(context.Background()); err != nil {
+		t.Fatalf("Expected no error after retries, got %v", err)
+	}
+	if calls != 3 {
+		t.Errorf("Expected 3 attempts, got %d", calls)
+	}
+}
+
+func TestRetry_GivesUpAfterMaxAttempts(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	client := newTestRetryClient(server.URL, 3)
+
+	err := client.DeleteJob(context.Background(), "123")
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusInternalServerError {
+		t.Fatalf("Expected APIError with status 500, got %v", err)
+	}
+	if calls != 3 {
+		t.Errorf("Expected 3 attempts, got %d", calls)
+	}
+}
+
+func TestRetry_NoRetryOnClientError(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	client := newTestRetryClient(server.URL, 4)
+
+	if _, err := client.GetJobDetails(context.Background(), "123"); err == nil {
+		t.Fatal("Expected an error, got nil")
+	}
+	if calls != 1 {
+		t.Errorf("Expected 1 attempt, got %d", calls)
+	}
+}
+
+func TestRetry_CreateNotRetriedOnServerError(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	client := newTestRetryClient(server.URL, 4)
+
+	if _, err := client.CreateJob(context.Background(), JobCreate{URL: "https://example.com"}); err == nil {
+		t.Fatal("Expected an error, got nil")
+	}
+	if calls != 1 {
+		t.Errorf("Expected PUT to be attempted once, got %d attempts", calls)
+	}
+}
+
+func TestRetry_CreateRetriedOnRefusedConnection(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		_, _ = w.Write([]byte(`{"jobId": 1}`))
+	}))
+	defer server.Close()
+
+	// The first dial is refused, so the request provably never reached the API.
+	var dials int32
+	dialer := &net.Dialer{}
+	client := newTestRetryClient(server.URL, 4)
+	client.HTTPClient = &http.Client{Transport: &http.Transport{
+		DialContext: func(ctx context.Context, network, addr string) (net.Conn, error) {
+			if atomic.AddInt32(&dials, 1) == 1 {
+				return nil, &net.OpError{Op: "dial", Net: network, Err: syscall.ECONNREFUSED}
+			}
+			return dialer.DialContext(ctx, network, addr)
+		},
+	}}
+
+	jobID, err := client.CreateJob(context.Background(), JobCreate{URL: "https://example.com"})
+	if err != nil {
+		t.Fatalf("Expected the create to be retried, got %v", err)
+	}
+	if jobID != 1 || dials != 2 || calls != 1 {
+		t.Errorf("Expected job 1 after 2 dials and 1 request, got job %d after %d dials and %d requests", jobID, dials, calls)
+	}
+}
+
+func TestRetry_CreateRetriedOnRateLimit(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"jobId": 42}`))
+	}))
+	defer server.Close()
+
+	client := newTestRetryClient(server.URL, 4)
+
+	jobID, err := client.CreateJob(context.Background(), JobCreate{URL: "https://example.com"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if jobID != 42 {
+		t.Errorf("Expected job ID 42, got %d", jobID)
+	}
+	if calls != 2 {
+		t.Errorf("Expected 2 attempts, got %d", calls)
+	}
+}
+
+func TestRetry_RetryAfterLongerThanMaxWait(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.Header().Set("Retry-After", "3600")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	client := newTestRetryClient(server.URL, 4)
+
+	if _, _, err := client.GetJobs(context.Background()); err == nil {
+		t.Fatal("Expected an error, got nil")
+	}
+	if calls != 1 {
+		t.Errorf("Expected 1 attempt when Retry-After exceeds the max wait, got %d", calls)
+	}
+}
+
+func TestRetry_ContextCanceledDuringWait(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key")
+	client.Retry = RetryPolicy{MaxAttempts: 4, MinWait: time.Hour, MaxWait: time.Hour}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, _, err := client.GetJobs(ctx)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected error to wrap context.DeadlineExceeded, got %v", err)
+	}
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Errorf("Expected error to also wrap the last APIError, got %v", err)
+	}
+}
+
+func TestRetryPolicy_Backoff(t *testing.T) {
+	p := RetryPolicy{MaxAttempts: 10, MinWait: 100 * time.Millisecond, MaxWait: time.Second}
+
+	for attempt := 1; attempt <= 6; attempt++ {
+		base := p.MinWait << (attempt - 1)
+		if base > p.MaxWait {
+			base = p.MaxWait
+		}
+		for i := 0; i < 20; i++ {
+			wait := p.backoff(attempt)
+			if wait < base/2 || wait > base {
+				t.Fatalf("attempt %d: expected wait in [%v, %v], got %v", attempt, base/2, base, wait)
+			}
+		}
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		value    string
+		expected time.Duration
+	}{
+		{"", 0},
+		{"5", 5 * time.Second},
+		{" 10 ", 10 * time.Second},
+		{"-3", 0},
+		{"Mon, 01 Jan 2024 12:00:30 GMT", 30 * time.Second},
+		{"Mon, 01 Jan 2024 11:00:00 GMT", 0},
+		{"soon", 0},
+	}
+
+	for _, tc := range testCases {
+		if got := parseRetryAfter(tc.value, now); got != tc.expected {
+			t.Errorf("parseRetryAfter(%q): expected %v, got %v", tc.value, tc.expected, got)
+		}
+	}
+}
@@ -0,0 +1,206 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+
+	// Embed the IANA time zone database so schedules resolve their time zone on
+	// systems without one, such as Windows or minimal containers.
+	_ "time/tzdata"
+)
+
+// expiresAtLayout is the layout of JobSchedule.ExpiresAt, e.g. 20250131235900.
+const expiresAtLayout = "20060102150405"
+
+// scheduleSearchYears bounds the search for run times of a schedule that matches
+// rarely or never, such as February 30. Leap days on a given weekday recur within
+// 28 years.
+const scheduleSearchYears = 30
+
+// Location returns the time zone of the schedule. An empty timezone means UTC.
+func (s JobSchedule) Location() (*time.Location, error) {
+	if s.Timezone == "" {
+		return time.UTC, nil
+	}
+	if s.Timezone == "Local" {
+		// time.LoadLocation maps "Local" to the time zone of this machine.
+		return nil, fmt.Errorf("invalid schedule timezone %q: expected an IANA time zone name such as Europe/Berlin", s.Timezone)
+	}
+	loc, err := time.LoadLocation(s.Timezone)
+	if err != nil {
+		return nil, fmt.Errorf("invalid schedule timezone %q: %w", s.Timezone, err)
+	}
+	return loc, nil
+}
+
+// ExpiresAtTime returns the time after which the schedule no longer runs, in the
+// schedule's time zone, or the zero time if it does not expire.
+func (s JobSchedule) ExpiresAtTime() (time.Time, error) {
+	if s.ExpiresAt == 0 {
+		return time.Time{}, nil
+	}
+	loc, err := s.Location()
+	if err != nil {
+		return time.Time{}, err
+	}
+	return ParseExpiresAt(s.ExpiresAt, loc)
+}
+
+// ParseExpiresAt converts an expiresAt value (YYYYMMDDhhmmss) into a time in loc.
+// Dates and times that do not exist on the calendar, such as 20250230000000, are
+// rejected.
+func ParseExpiresAt(expiresAt int, loc *time.Location) (time.Time, error) {
+	t, err := time.ParseInLocation(expiresAtLayout, fmt.Sprintf("%014d", expiresAt), loc)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid schedule expiresAt %d: expected YYYYMMDDhhmmss: %w", expiresAt, err)
+	}
+	return t, nil
+}
+
+// FormatExpiresAt converts t into an expiresAt value (YYYYMMDDhhmmss) in t's location.
+func FormatExpiresAt(t time.Time) int {
+	// The layout only produces digits, so the conversion cannot fail for years 0-9999.
+	expiresAt, _ := strconv.Atoi(t.Format(expiresAtLayout))
+	return expiresAt
+}
+
+// Next returns up to n times after the given time at which the schedule runs, in the
+// schedule's time zone. A time matches if its minute, hour, day of month, month and
+// day of week are all listed in the schedule, where an empty list or [-1] matches
+// every value. Local times skipped by a daylight saving time change do not run, and
+// no times after ExpiresAt are returned. Fewer than n times are returned if the
+// schedule expires or does not match within the next 30 years.
+func (s JobSchedule) Next(after time.Time, n int) ([]time.Time, error) {
+	loc, err := s.Location()
+	if err != nil {
+		return nil, err
+	}
+	expiresAt, err := s.ExpiresAtTime()
+	if err != nil {
+		return nil, err
+	}
+
+	// Walk the wall clock of the schedule's time zone. Calendar arithmetic is done
+	// in UTC, which has no daylight saving time.
+	local := after.In(loc)
+	wall := time.Date(local.Year(), local.Month(), local.Day(), local.Hour(), local.Minute()+1, 0, 0, time.UTC)
+	limit := wall.AddDate(scheduleSearchYears, 0, 0)
+
+	var runs []time.Time
+	for len(runs) < n && wall.Before(limit) {
+		switch {
+		case !scheduleMatches(s.Months, int(wall.Month())):
+			wall = time.Date(wall.Year(), wall.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+		case !scheduleMatches(s.MDays, wall.Day()) || !scheduleMatches(s.WDays, int(wall.Weekday())):
+			wall = time.Date(wall.Year(), wall.Month(), wall.Day()+1, 0, 0, 0, 0, time.UTC)
+		case !scheduleMatches(s.Hours, wall.Hour()):
+			wall = wall.Truncate(time.Hour).Add(time.Hour)
+		case !scheduleMatches(s.Minutes, wall.Minute()):
+			wall = wall.Add(time.Minute)
+		default:
+			run := time.Date(wall.Year(), wall.Month(), wall.Day(), wall.Hour(), wall.Minute(), 0, 0, loc)
+			if !expiresAt.IsZero() && run.After(expiresAt) {
+				return runs, nil
+			}
+			// time.Date moves nonexistent local times out of the daylight saving gap.
+			if run.Hour() == wall.Hour() && run.Minute() == wall.Minute() && run.After(after) {
+				runs = append(runs, run)
+			}
+			wall = wall.Add(time.Minute)
+		}
+	}
+	return runs, nil
+}
+
+// NormalizeScheduleList returns a schedule time list in canonical form: sorted and
+// without duplicates, or [-1] if the list is empty or contains -1.
+func NormalizeScheduleList(values []int) []int {
+	if len(values) == 0 || slices.Contains(values, -1) {
+		return []int{-1}
+	}
+	normalized := slices.Clone(values)
+	slices.Sort(normalized)
+	return slices.Compact(normalized)
+}
+
+// Normalize returns the schedule with its time lists in canonical form, see
+// NormalizeScheduleList. The normalized schedule runs at the same times.
+func (s JobSchedule) Normalize() JobSchedule {
+	s.Hours = NormalizeScheduleList(s.Hours)
+	s.MDays = NormalizeScheduleList(s.MDays)
+	s.Minutes = NormalizeScheduleList(s.Minutes)
+	s.Months = NormalizeScheduleList(s.Months)
+	s.WDays = NormalizeScheduleList(s.WDays)
+	return s
+}
+
+// scheduleMatches reports whether value is allowed by a schedule list.
+func scheduleMatches(values []int, value int) bool {
+	return len(values) == 0 || slices.Contains(values, -1) || slices.Contains(values, value)
+}
+
+// DaylightSavingRun is a run time of a schedule that a daylight saving time change
+// skips or repeats.
+type DaylightSavingRun struct {
+	// Wall is the local date and time of the run. It is expressed in UTC because
+	// skipped times do not exist in the schedule's time zone.
+	Wall time.Time
+	// Skipped is true if the clocks move forward past Wall, and false if they move
+	// back so that Wall occurs twice.
+	Skipped bool
+}
+
+// DaylightSavingRuns returns the local run times of the schedule that fall into a
+// daylight saving time change of its time zone between after and until.
+func (s JobSchedule) DaylightSavingRuns(after, until time.Time) ([]DaylightSavingRun, error) {
+	loc, err := s.Location()
+	if err != nil {
+		return nil, err
+	}
+	expiresAt, err := s.ExpiresAtTime()
+	if err != nil {
+		return nil, err
+	}
+	if !expiresAt.IsZero() && expiresAt.Before(until) {
+		until = expiresAt
+	}
+
+	var runs []DaylightSavingRun
+	for t := after.In(loc); ; {
+		_, change := t.ZoneBounds()
+		if change.IsZero() || !change.Before(until) {
+			return runs, nil
+		}
+		_, offsetBefore := change.Add(-time.Second).Zone()
+		_, offsetAfter := change.Zone()
+		shift := time.Duration(offsetAfter-offsetBefore) * time.Second
+
+		// The wall clock jumps from changeWall to changeWall+shift.
+		changeWall := change.UTC().Add(time.Duration(offsetBefore) * time.Second)
+		from, to := changeWall, changeWall.Add(shift)
+		if shift < 0 {
+			from, to = to, from
+		}
+		for wall := from; wall.Before(to); wall = wall.Add(time.Minute) {
+			if s.matchesWall(wall) {
+				runs = append(runs, DaylightSavingRun{Wall: wall, Skipped: shift > 0})
+			}
+		}
+		t = change
+	}
+}
+
+// matchesWall reports whether the schedule runs at the given wall clock time,
+// expressed in UTC.
+func (s JobSchedule) matchesWall(wall time.Time) bool {
+	return scheduleMatches(s.Months, int(wall.Month())) &&
+		scheduleMatches(s.MDays, wall.Day()) &&
+		scheduleMatches(s.WDays, int(wall.Weekday())) &&
+		scheduleMatches(s.Hours, wall.Hour()) &&
+		scheduleMatches(s.Minutes, wall.Minute())
+}
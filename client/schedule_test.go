@@ -0,0 +1,213 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestJobSchedule_Next(t *testing.T) {
+	tests := []struct {
+		name     string
+		schedule JobSchedule
+		cron     string
+		after    string
+		n        int
+		want     []string
+	}{
+		{
+			name:     "every minute",
+			schedule: JobSchedule{Timezone: "UTC", Hours: []int{-1}, MDays: []int{-1}, Minutes: []int{-1}, Months: []int{-1}, WDays: []int{-1}},
+			after:    "2025-01-01T10:00:30Z",
+			n:        2,
+			want:     []string{"2025-01-01T10:01:00Z", "2025-01-01T10:02:00Z"},
+		},
+		{
+			name:     "business hours over a weekend",
+			schedule: JobSchedule{Timezone: "Europe/Berlin"},
+			cron:     "*/15 9-17 * * 1-5",
+			after:    "2025-01-03T17:50:00+01:00",
+			n:        3,
+			want:     []string{"2025-01-06T09:00:00+01:00", "2025-01-06T09:15:00+01:00", "2025-01-06T09:30:00+01:00"},
+		},
+		{
+			name:     "time zone of the schedule",
+			schedule: JobSchedule{Timezone: "America/New_York"},
+			cron:     "0 9 * * *",
+			after:    "2025-07-01T00:00:00Z",
+			n:        1,
+			want:     []string{"2025-07-01T09:00:00-04:00"},
+		},
+		{
+			name:     "expires",
+			schedule: JobSchedule{Timezone: "UTC", ExpiresAt: 20250101020000},
+			cron:     "0 * * * *",
+			after:    "2025-01-01T00:00:00Z",
+			n:        5,
+			want:     []string{"2025-01-01T01:00:00Z", "2025-01-01T02:00:00Z"},
+		},
+		{
+			name:     "skipped by daylight saving time",
+			schedule: JobSchedule{Timezone: "Europe/Berlin"},
+			cron:     "30 2 * * *",
+			after:    "2025-03-29T03:00:00+01:00",
+			n:        1,
+			want:     []string{"2025-03-31T02:30:00+02:00"},
+		},
+		{
+			name:     "leap day",
+			schedule: JobSchedule{Timezone: "UTC"},
+			cron:     "0 0 29 2 *",
+			after:    "2025-01-01T00:00:00Z",
+			n:        2,
+			want:     []string{"2028-02-29T00:00:00Z", "2032-02-29T00:00:00Z"},
+		},
+		{
+			name:     "never",
+			schedule: JobSchedule{Timezone: "UTC"},
+			cron:     "0 0 30 2 *",
+			after:    "2025-01-01T00:00:00Z",
+			n:        1,
+			want:     nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schedule := tt.schedule
+			if tt.cron != "" {
+				times, err := ParseCronExpression(tt.cron)
+				if err != nil {
+					t.Fatalf("Expected no error, got %v", err)
+				}
+				schedule.Hours, schedule.MDays, schedule.Minutes, schedule.Months, schedule.WDays =
+					times.Hours, times.MDays, times.Minutes, times.Months, times.WDays
+			}
+			after, err := time.Parse(time.RFC3339, tt.after)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			runs, err := schedule.Next(after, tt.n)
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			got := make([]string, len(runs))
+			for i, run := range runs {
+				got[i] = run.Format(time.RFC3339)
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("Next() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestJobSchedule_NextInvalid(t *testing.T) {
+	tests := map[string]JobSchedule{
+		"invalid schedule timezone":  {Timezone: "Mars/Olympus_Mons"},
+		"invalid schedule expiresAt": {Timezone: "UTC", ExpiresAt: 20250230000000},
+	}
+
+	for want, schedule := range tests {
+		t.Run(want, func(t *testing.T) {
+			_, err := schedule.Next(time.Now(), 1)
+			if err == nil || !strings.Contains(err.Error(), want) {
+				t.Errorf("Expected error containing %q, got %v", want, err)
+			}
+		})
+	}
+}
+
+func TestJobSchedule_ExpiresAtTime(t *testing.T) {
+	expiresAt, err := JobSchedule{Timezone: "Europe/Berlin", ExpiresAt: 20251231235900}.ExpiresAtTime()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if got := expiresAt.Format(time.RFC3339); got != "2025-12-31T23:59:00+01:00" {
+		t.Errorf("ExpiresAtTime() = %s", got)
+	}
+
+	if expiresAt, err := (JobSchedule{}).ExpiresAtTime(); err != nil || !expiresAt.IsZero() {
+		t.Errorf("Expected the zero time for a schedule that does not expire, got %v, %v", expiresAt, err)
+	}
+}
+
+func TestParseExpiresAt(t *testing.T) {
+	for _, valid := range []int{20251231235959, 20240229000000, 19700101000000} {
+		if _, err := ParseExpiresAt(valid, time.UTC); err != nil {
+			t.Errorf("Expected %d to be valid, got %v", valid, err)
+		}
+	}
+	for _, invalid := range []int{20251399000000, 20250230000000, 20250229000000, 20251231240000, 20251231235960, 2025, -1} {
+		if _, err := ParseExpiresAt(invalid, time.UTC); err == nil {
+			t.Errorf("Expected %d to be rejected", invalid)
+		}
+	}
+}
+
+func TestFormatExpiresAt(t *testing.T) {
+	loc, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expiresAt := time.Date(2025, time.December, 31, 15, 0, 0, 0, time.UTC).In(loc)
+
+	if got := FormatExpiresAt(expiresAt); got != 20260101000000 {
+		t.Errorf("FormatExpiresAt() = %d, want 20260101000000", got)
+	}
+}
+
+func TestJobSchedule_DaylightSavingRuns(t *testing.T) {
+	schedule := JobSchedule{Timezone: "Europe/Berlin", Hours: []int{1, 2}, Minutes: []int{30}}
+	after := time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+	until := time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	runs, err := schedule.DaylightSavingRuns(after, until)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	want := []DaylightSavingRun{
+		{Wall: time.Date(2025, time.March, 30, 2, 30, 0, 0, time.UTC), Skipped: true},
+		{Wall: time.Date(2025, time.October, 26, 2, 30, 0, 0, time.UTC), Skipped: false},
+	}
+	if !slices.Equal(runs, want) {
+		t.Errorf("DaylightSavingRuns() = %v, want %v", runs, want)
+	}
+
+	for _, schedule := range []JobSchedule{
+		{Timezone: "UTC", Hours: []int{2}, Minutes: []int{30}},
+		{Timezone: "Europe/Berlin", Hours: []int{2}, Minutes: []int{30}, ExpiresAt: 20250301000000},
+		{Timezone: "Europe/Berlin", Hours: []int{2}, Minutes: []int{30}, WDays: []int{1}},
+	} {
+		if runs, err := schedule.DaylightSavingRuns(after, until); err != nil || len(runs) != 0 {
+			t.Errorf("Expected no runs for %+v, got %v, %v", schedule, runs, err)
+		}
+	}
+}
+
+func TestNormalizeScheduleList(t *testing.T) {
+	tests := []struct {
+		in   []int
+		want []int
+	}{
+		{nil, []int{-1}},
+		{[]int{-1}, []int{-1}},
+		{[]int{5, -1}, []int{-1}},
+		{[]int{17, 9}, []int{9, 17}},
+		{[]int{30, 0, 30, 15}, []int{0, 15, 30}},
+	}
+	for _, tt := range tests {
+		in := slices.Clone(tt.in)
+		if got := NormalizeScheduleList(tt.in); !slices.Equal(got, tt.want) {
+			t.Errorf("NormalizeScheduleList(%v) = %v, want %v", tt.in, got, tt.want)
+		}
+		if !slices.Equal(tt.in, in) {
+			t.Errorf("NormalizeScheduleList modified its argument: %v", tt.in)
+		}
+	}
+}
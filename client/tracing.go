@@ -0,0 +1,87 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TracerName is the name of the OpenTelemetry tracer that creates the client's spans.
+const TracerName = "github.com/plain-insure/terraform-provider-cronjoborg/client"
+
+// Span attributes specific to the cron-job.org API. Method, status code and retry
+// count use the OpenTelemetry HTTP semantic conventions.
+const (
+	// AttributeEndpoint is the request path with IDs replaced by placeholders, e.g. /jobs/{jobId}.
+	AttributeEndpoint = attribute.Key("cronjoborg.endpoint")
+	// AttributeJobID is the ID of the job the request refers to, if any.
+	AttributeJobID = attribute.Key("cronjoborg.job_id")
+	// AttributeRateLimitWait is the total time in milliseconds spent waiting for the
+	// client-side rate limiter, over all attempts.
+	AttributeRateLimitWait = attribute.Key("cronjoborg.rate_limit_wait_ms")
+)
+
+// tracerOrGlobal returns the client's tracer, or the tracer of the global
+// TracerProvider if the client was not created by NewClient.
+func (c *Client) tracerOrGlobal() trace.Tracer {
+	if c.tracer != nil {
+		return c.tracer
+	}
+	return otel.Tracer(TracerName)
+}
+
+// startSpan starts the span of an API call, which covers all of its attempts.
+func (c *Client) startSpan(ctx context.Context, method, path string) (context.Context, trace.Span) {
+	endpoint, jobID := endpointTemplate(path)
+	attrs := []attribute.KeyValue{
+		semconv.HTTPRequestMethodKey.String(method),
+		AttributeEndpoint.String(endpoint),
+	}
+	if jobID != "" {
+		attrs = append(attrs, AttributeJobID.String(jobID))
+	}
+	return c.tracerOrGlobal().Start(ctx, method+" "+endpoint,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attrs...),
+	)
+}
+
+// endSpan records the outcome of an API call and ends its span.
+func endSpan(span trace.Span, attempts int, rateLimitWait time.Duration, statusCode int, err error) {
+	span.SetAttributes(
+		semconv.HTTPRequestResendCount(attempts-1),
+		AttributeRateLimitWait.Int64(rateLimitWait.Milliseconds()),
+	)
+	if statusCode != 0 {
+		span.SetAttributes(semconv.HTTPResponseStatusCode(statusCode))
+	}
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// endpointTemplate returns path with the job ID and history identifier replaced by
+// placeholders, and the job ID.
+func endpointTemplate(path string) (endpoint, jobID string) {
+	segments := strings.Split(strings.TrimPrefix(path, "/"), "/")
+	if len(segments) < 2 || segments[0] != "jobs" {
+		return path, ""
+	}
+	jobID = segments[1]
+	segments[1] = "{jobId}"
+	if len(segments) >= 4 && segments[2] == "history" {
+		segments[3] = "{identifier}"
+	}
+	return "/" + strings.Join(segments, "/"), jobID
+}
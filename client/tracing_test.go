@@ -0,0 +1,137 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func newTracingTestClient(t *testing.T, handler http.HandlerFunc) (*Client, *tracetest.InMemoryExporter) {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	t.Cleanup(func() { _ = tp.Shutdown(context.Background()) })
+
+	c := NewClient(server.URL, "test-key",
+		WithTracerProvider(tp),
+		WithRetry(RetryPolicy{MaxAttempts: 3, MinWait: time.Millisecond, MaxWait: time.Millisecond}),
+	)
+	c.RateLimiter = NewRateLimiter(map[EndpointClass][]RateLimit{
+		EndpointClassDefault: {{Requests: 1, Period: 20 * time.Millisecond}},
+	})
+	return c, exporter
+}
+
+func spanAttributes(span tracetest.SpanStub) map[attribute.Key]attribute.Value {
+	attrs := make(map[attribute.Key]attribute.Value, len(span.Attributes))
+	for _, kv := range span.Attributes {
+		attrs[kv.Key] = kv.Value
+	}
+	return attrs
+}
+
+func TestClient_Tracing(t *testing.T) {
+	var calls atomic.Int32
+	c, exporter := newTracingTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(`{"jobDetails": {"jobId": 123}}`))
+	})
+
+	if _, err := c.GetJobDetails(context.Background(), "123"); err != nil {
+		t.Fatal(err)
+	}
+
+	spans := exporter.GetSpans()
+	if len(spans) != 1 {
+		t.Fatalf("Expected 1 span for the API call, got %d", len(spans))
+	}
+	span := spans[0]
+	if span.Name != "GET /jobs/{jobId}" || span.SpanKind != trace.SpanKindClient {
+		t.Errorf("Unexpected span %q of kind %v", span.Name, span.SpanKind)
+	}
+	if span.Status.Code == codes.Error {
+		t.Errorf("Expected a successful span, got %+v", span.Status)
+	}
+
+	attrs := spanAttributes(span)
+	expected := map[attribute.Key]attribute.Value{
+		"http.request.method":       attribute.StringValue("GET"),
+		"http.response.status_code": attribute.IntValue(200),
+		"http.request.resend_count": attribute.IntValue(1),
+		AttributeEndpoint:           attribute.StringValue("/jobs/{jobId}"),
+		AttributeJobID:              attribute.StringValue("123"),
+	}
+	for key, want := range expected {
+		if got, ok := attrs[key]; !ok || got != want {
+			t.Errorf("Expected attribute %s = %v, got %v", key, want.Emit(), got.Emit())
+		}
+	}
+	// The retry had to wait for the rate limiter after the first attempt.
+	if wait, ok := attrs[AttributeRateLimitWait]; !ok || wait.AsInt64() <= 0 {
+		t.Errorf("Expected a positive rate limiter wait, got %v", wait.Emit())
+	}
+}
+
+func TestClient_TracingError(t *testing.T) {
+	c, exporter := newTracingTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	if err := c.DeleteJob(context.Background(), "7"); err == nil {
+		t.Fatal("Expected an error")
+	}
+
+	spans := exporter.GetSpans()
+	if len(spans) != 1 {
+		t.Fatalf("Expected 1 span, got %d", len(spans))
+	}
+	if spans[0].Status.Code != codes.Error {
+		t.Errorf("Expected an error status, got %+v", spans[0].Status)
+	}
+	attrs := spanAttributes(spans[0])
+	if got := attrs["http.response.status_code"]; got != attribute.IntValue(404) {
+		t.Errorf("Expected status code 404, got %v", got.Emit())
+	}
+	if got := attrs["http.request.resend_count"]; got != attribute.IntValue(0) {
+		t.Errorf("Expected no resends, got %v", got.Emit())
+	}
+}
+
+func TestEndpointTemplate(t *testing.T) {
+	tests := []struct {
+		path     string
+		endpoint string
+		jobID    string
+	}{
+		{path: "/jobs", endpoint: "/jobs"},
+		{path: "/jobs/42", endpoint: "/jobs/{jobId}", jobID: "42"},
+		{path: "/jobs/42/history", endpoint: "/jobs/{jobId}/history", jobID: "42"},
+		{path: "/jobs/42/history/abc", endpoint: "/jobs/{jobId}/history/{identifier}", jobID: "42"},
+	}
+
+	for _, tt := range tests {
+		endpoint, jobID := endpointTemplate(tt.path)
+		if endpoint != tt.endpoint || jobID != tt.jobID {
+			t.Errorf("endpointTemplate(%q) = %q, %q, want %q, %q", tt.path, endpoint, jobID, tt.endpoint, tt.jobID)
+		}
+	}
+}
@@ -45,11 +45,21 @@ resource "cronjoborg_job" "database_health" {
 resource "cronjoborg_job" "daily_backup" {
   title = "Daily Database Backup"
   url   = "https://backup.mywebsite.com/trigger/daily"
+
+  schedule {
+    timezone        = "Europe/Berlin"
+    cron_expression = "30 2 * * *"
+  }
 }
 
 resource "cronjoborg_job" "weekly_backup" {
   title = "Weekly Full Backup"
   url   = "https://backup.mywebsite.com/trigger/weekly"
+
+  schedule {
+    timezone        = "Europe/Berlin"
+    cron_expression = "0 4 * * SUN"
+  }
 }
 
 # Maintenance jobs
@@ -0,0 +1,41 @@
+terraform {
+  required_providers {
+    cronjoborg = {
+      source = "registry.terraform.io/plain-insure/cronjoborg"
+    }
+  }
+}
+
+provider "cronjoborg" {
+  # API key can be set via CRON_JOB_API_KEY environment variable
+  # or specified here (not recommended for production)
+  # api_key = "your-api-key-here"
+}
+
+# The history list does not include response headers and body,
+# so look up the details of the most recent failed execution
+data "cronjoborg_job_history" "example" {
+  job_id = 123 # Replace with actual job ID
+}
+
+locals {
+  failed_executions = [
+    for execution in data.cronjoborg_job_history.example.history : execution
+    if execution.status >= 2
+  ]
+}
+
+data "cronjoborg_job_history_item" "last_failure" {
+  count = length(local.failed_executions) > 0 ? 1 : 0
+
+  job_id     = data.cronjoborg_job_history.example.job_id
+  identifier = local.failed_executions[0].identifier
+}
+
+output "last_failure_http_status" {
+  value = length(data.cronjoborg_job_history_item.last_failure) > 0 ? data.cronjoborg_job_history_item.last_failure[0].http_status : null
+}
+
+output "last_failure_response_body" {
+  value = length(data.cronjoborg_job_history_item.last_failure) > 0 ? data.cronjoborg_job_history_item.last_failure[0].body : null
+}
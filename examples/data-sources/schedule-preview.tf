@@ -0,0 +1,41 @@
+terraform {
+  required_providers {
+    cronjoborg = {
+      source = "registry.terraform.io/plain-insure/cronjoborg"
+    }
+  }
+}
+
+provider "cronjoborg" {
+  # API key can be set via CRON_JOB_API_KEY environment variable
+  # or specified here (not recommended for production)
+  # api_key = "your-api-key-here"
+}
+
+locals {
+  report_schedule = {
+    timezone        = "Europe/Berlin"
+    cron_expression = "0 8 * * MON-FRI"
+  }
+}
+
+# Check when the job will run before applying it
+data "cronjoborg_schedule_preview" "report" {
+  timezone        = local.report_schedule.timezone
+  cron_expression = local.report_schedule.cron_expression
+  run_count       = 3
+}
+
+resource "cronjoborg_job" "report" {
+  title = "Daily Report"
+  url   = "https://example.com/report"
+
+  schedule {
+    timezone        = local.report_schedule.timezone
+    cron_expression = local.report_schedule.cron_expression
+  }
+}
+
+output "report_next_runs" {
+  value = data.cronjoborg_schedule_preview.report.next_runs
+}
@@ -0,0 +1,2 @@
+# Jobs can be imported using the numeric job ID shown in the cron-job.org console
+terraform import cronjoborg_job.example 12345
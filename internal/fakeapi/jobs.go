@@ -0,0 +1,414 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package fakeapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/plain-insure/terraform-provider-cronjoborg/client"
+)
+
+// readOnlyJobFields are job fields that are set by the API and ignored in requests.
+var readOnlyJobFields = []string{"jobId", "lastStatus", "lastDuration", "lastExecution", "nextExecution", "type"}
+
+// defaultJob returns the settings of a newly created job for all fields not sent by the client.
+func defaultJob() client.DetailedJob {
+	return client.DetailedJob{
+		Job: client.Job{
+			RequestTimeout: -1,
+			Schedule: client.JobSchedule{
+				Timezone: "UTC",
+				Hours:    []int{-1},
+				MDays:    []int{-1},
+				Minutes:  []int{-1},
+				Months:   []int{-1},
+				WDays:    []int{-1},
+			},
+		},
+		ExtendedData: client.JobExtendedData{Headers: map[string]string{}},
+	}
+}
+
+// AddJob stores a job as if it had been created through the API and returns its ID.
+// A zero JobID is replaced by the next free ID.
+func (a *API) AddJob(job client.DetailedJob) int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if job.JobID == 0 {
+		job.JobID = a.nextJobID
+	}
+	if job.JobID >= a.nextJobID {
+		a.nextJobID = job.JobID + 1
+	}
+	a.jobs[job.JobID] = job
+	return job.JobID
+}
+
+// Job returns the stored job with the given ID.
+func (a *API) Job(jobID int) (client.DetailedJob, bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	job, ok := a.jobs[jobID]
+	return job, ok
+}
+
+// Jobs returns all stored jobs ordered by ID.
+func (a *API) Jobs() []client.DetailedJob {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	return a.sortedJobs()
+}
+
+// UpdateJob modifies a stored job in place, e.g. to simulate a change made in the console.
+// It reports whether the job exists.
+func (a *API) UpdateJob(jobID int, update func(job *client.DetailedJob)) bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	job, ok := a.jobs[jobID]
+	if !ok {
+		return false
+	}
+	update(&job)
+	job.JobID = jobID
+	a.jobs[jobID] = job
+	return true
+}
+
+// DeleteJob removes a stored job and its history, e.g. to simulate a deletion made in the console.
+// It reports whether the job existed.
+func (a *API) DeleteJob(jobID int) bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	_, ok := a.jobs[jobID]
+	delete(a.jobs, jobID)
+	delete(a.history, jobID)
+	return ok
+}
+
+// AddHistory records an execution of a job and returns the identifier of the history item.
+// The job's last execution fields are updated accordingly.
+func (a *API) AddHistory(jobID int, item client.JobHistory) (string, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	job, ok := a.jobs[jobID]
+	if !ok {
+		return "", fmt.Errorf("job %d does not exist", jobID)
+	}
+
+	if item.Date == 0 {
+		item.Date = int(a.opts.Now().Unix())
+	}
+	if item.DatePlanned == 0 {
+		item.DatePlanned = item.Date
+	}
+	if item.URL == "" {
+		item.URL = job.URL
+	}
+	date := time.Unix(int64(item.Date), 0).UTC()
+	item.JobID = jobID
+	item.JobLogID = a.nextLogID
+	item.Identifier = fmt.Sprintf("%d-%d-%d-%d", jobID, date.Day(), date.Hour(), item.JobLogID)
+	a.nextLogID++
+
+	// The API lists the most recent execution first.
+	a.history[jobID] = append([]client.JobHistory{item}, a.history[jobID]...)
+
+	job.LastStatus = item.Status
+	job.LastDuration = item.Duration
+	job.LastExecution = item.Date
+	a.jobs[jobID] = job
+
+	return item.Identifier, nil
+}
+
+// sortedJobs returns all jobs ordered by ID. The caller must hold a.mu.
+func (a *API) sortedJobs() []client.DetailedJob {
+	ids := make([]int, 0, len(a.jobs))
+	for id := range a.jobs {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+
+	jobs := make([]client.DetailedJob, len(ids))
+	for i, id := range ids {
+		jobs[i] = a.jobs[id]
+	}
+	return jobs
+}
+
+func (a *API) listJobs(w http.ResponseWriter, r *http.Request) {
+	a.mu.Lock()
+	detailed := a.sortedJobs()
+	a.mu.Unlock()
+
+	// The list only contains the basic job fields.
+	jobs := make([]client.Job, len(detailed))
+	for i, job := range detailed {
+		jobs[i] = job.Job
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"jobs":       jobs,
+		"someFailed": false,
+	})
+}
+
+func (a *API) createJob(w http.ResponseWriter, r *http.Request) {
+	fields, ok := decodeJobRequest(w, r)
+	if !ok {
+		return
+	}
+	if url, _ := fields["url"].(string); url == "" {
+		writeError(w, http.StatusBadRequest, "Invalid request: url is required")
+		return
+	}
+
+	job, err := mergeJob(defaultJob(), fields)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	a.mu.Lock()
+	job.JobID = a.nextJobID
+	a.nextJobID++
+	a.jobs[job.JobID] = job
+	a.mu.Unlock()
+
+	writeJSON(w, http.StatusOK, map[string]int{"jobId": job.JobID})
+}
+
+func (a *API) getJob(w http.ResponseWriter, r *http.Request) {
+	job, ok := a.lookupJob(w, r)
+	if !ok {
+		return
+	}
+
+	details, err := encodeJobDetails(job)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"jobDetails": details})
+}
+
+func (a *API) updateJob(w http.ResponseWriter, r *http.Request) {
+	job, ok := a.lookupJob(w, r)
+	if !ok {
+		return
+	}
+	fields, ok := decodeJobRequest(w, r)
+	if !ok {
+		return
+	}
+
+	updated, err := mergeJob(job, fields)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	a.mu.Lock()
+	// The job may have been deleted concurrently.
+	if _, exists := a.jobs[job.JobID]; !exists {
+		a.mu.Unlock()
+		writeError(w, http.StatusNotFound, "Job not found")
+		return
+	}
+	a.jobs[job.JobID] = updated
+	a.mu.Unlock()
+
+	writeJSON(w, http.StatusOK, struct{}{})
+}
+
+func (a *API) deleteJob(w http.ResponseWriter, r *http.Request) {
+	job, ok := a.lookupJob(w, r)
+	if !ok {
+		return
+	}
+
+	a.DeleteJob(job.JobID)
+	writeJSON(w, http.StatusOK, struct{}{})
+}
+
+func (a *API) getHistory(w http.ResponseWriter, r *http.Request) {
+	job, ok := a.lookupJob(w, r)
+	if !ok {
+		return
+	}
+
+	a.mu.Lock()
+	history := append([]client.JobHistory{}, a.history[job.JobID]...)
+	a.mu.Unlock()
+
+	// Headers and body are only returned by the history item endpoint.
+	for i := range history {
+		history[i].Headers = nil
+		history[i].Body = nil
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"history":     history,
+		"predictions": []int{},
+	})
+}
+
+func (a *API) getHistoryItem(w http.ResponseWriter, r *http.Request) {
+	job, ok := a.lookupJob(w, r)
+	if !ok {
+		return
+	}
+	identifier := r.PathValue("identifier")
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	for _, item := range a.history[job.JobID] {
+		if item.Identifier == identifier {
+			writeJSON(w, http.StatusOK, map[string]interface{}{"jobHistoryDetails": item})
+			return
+		}
+	}
+	writeError(w, http.StatusNotFound, "History item not found")
+}
+
+// lookupJob returns the job addressed by the jobId path parameter, or writes a 404 response.
+func (a *API) lookupJob(w http.ResponseWriter, r *http.Request) (client.DetailedJob, bool) {
+	jobID, err := strconv.Atoi(r.PathValue("jobId"))
+	if err == nil {
+		if job, ok := a.Job(jobID); ok {
+			return job, true
+		}
+	}
+	writeError(w, http.StatusNotFound, "Job not found")
+	return client.DetailedJob{}, false
+}
+
+// decodeJobRequest decodes the "job" object of a create or update request, or writes a 400 response.
+func decodeJobRequest(w http.ResponseWriter, r *http.Request) (map[string]interface{}, bool) {
+	var req struct {
+		Job map[string]interface{} `json:"job"`
+	}
+	decoder := json.NewDecoder(r.Body)
+	decoder.UseNumber()
+	if err := decoder.Decode(&req); err != nil || req.Job == nil {
+		writeError(w, http.StatusBadRequest, "Invalid request: expected a job object")
+		return nil, false
+	}
+	for _, field := range readOnlyJobFields {
+		delete(req.Job, field)
+	}
+	return req.Job, true
+}
+
+// mergeJob applies the fields of a request to a job. Top-level objects such as the
+// schedule are merged key by key; all other values, including the header map, replace
+// the current value. Unknown fields and invalid values are rejected.
+func mergeJob(job client.DetailedJob, fields map[string]interface{}) (client.DetailedJob, error) {
+	current, err := toMap(job)
+	if err != nil {
+		return job, err
+	}
+
+	for key, value := range fields {
+		existing, known := current[key]
+		if !known {
+			return job, fmt.Errorf("Invalid request: unknown job field %q", key)
+		}
+		existingObj, existingIsObj := existing.(map[string]interface{})
+		valueObj, valueIsObj := value.(map[string]interface{})
+		if existingIsObj && valueIsObj {
+			for k, v := range valueObj {
+				existingObj[k] = v
+			}
+			continue
+		}
+		current[key] = value
+	}
+
+	data, err := json.Marshal(current)
+	if err != nil {
+		return job, err
+	}
+	var merged client.DetailedJob
+	if err := json.Unmarshal(data, &merged); err != nil {
+		return job, fmt.Errorf("Invalid request: %s", err)
+	}
+	if err := validateJob(merged); err != nil {
+		return job, err
+	}
+	return merged, nil
+}
+
+// validateJob checks the value ranges documented for the Job and JobSchedule objects.
+func validateJob(job client.DetailedJob) error {
+	if !job.RequestMethod.Valid() {
+		return fmt.Errorf("Invalid request: invalid requestMethod %d", job.RequestMethod)
+	}
+	if _, err := time.LoadLocation(job.Schedule.Timezone); err != nil || job.Schedule.Timezone == "" {
+		return fmt.Errorf("Invalid request: invalid timezone %q", job.Schedule.Timezone)
+	}
+
+	ranges := []struct {
+		name     string
+		values   []int
+		min, max int
+	}{
+		{"hours", job.Schedule.Hours, 0, 23},
+		{"mdays", job.Schedule.MDays, 1, 31},
+		{"minutes", job.Schedule.Minutes, 0, 59},
+		{"months", job.Schedule.Months, 1, 12},
+		{"wdays", job.Schedule.WDays, 0, 6},
+	}
+	for _, r := range ranges {
+		for _, v := range r.values {
+			if v != -1 && (v < r.min || v > r.max) {
+				return fmt.Errorf("Invalid request: invalid schedule %s value %d", r.name, v)
+			}
+		}
+	}
+	return nil
+}
+
+// encodeJobDetails encodes a job like the API does, which returns an empty
+// header map as an empty JSON array.
+func encodeJobDetails(job client.DetailedJob) (map[string]interface{}, error) {
+	details, err := toMap(job)
+	if err != nil {
+		return nil, err
+	}
+	if len(job.ExtendedData.Headers) == 0 {
+		if extendedData, ok := details["extendedData"].(map[string]interface{}); ok {
+			extendedData["headers"] = []interface{}{}
+		}
+	}
+	return details, nil
+}
+
+// toMap converts a job to its generic JSON representation.
+func toMap(job client.DetailedJob) (map[string]interface{}, error) {
+	data, err := json.Marshal(job)
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var m map[string]interface{}
+	if err := decoder.Decode(&m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
@@ -0,0 +1,222 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+// Package fakeapi implements an in-memory fake of the cron-job.org REST API
+// for offline tests. It serves the job endpoints in the documented JSON format,
+// keeps state between requests, checks the bearer API key and can enforce rate
+// limits and a daily request quota.
+package fakeapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/plain-insure/terraform-provider-cronjoborg/client"
+)
+
+// DefaultAPIKey is the API key accepted by a server created with zero Options.
+const DefaultAPIKey = "fake-api-key"
+
+// RateLimit allows at most Requests requests in any window of length Period.
+type RateLimit struct {
+	Requests int
+	Period   time.Duration
+}
+
+// Options configures the fake API.
+type Options struct {
+	// APIKey is the bearer token clients must send. Defaults to DefaultAPIKey.
+	APIKey string
+	// RateLimit applies to all requests (0 = unlimited).
+	RateLimit RateLimit
+	// CreateRateLimit applies to job creation (PUT /jobs) in addition to RateLimit (0 = unlimited).
+	CreateRateLimit RateLimit
+	// DailyQuota is the maximum number of authorized requests per UTC day (0 = unlimited).
+	DailyQuota int
+	// Now returns the current time. Defaults to time.Now.
+	Now func() time.Time
+}
+
+// API is the fake API handler. It is safe for concurrent use.
+type API struct {
+	opts Options
+	mux  *http.ServeMux
+
+	mu         sync.Mutex
+	jobs       map[int]client.DetailedJob
+	history    map[int][]client.JobHistory
+	nextJobID  int
+	nextLogID  int
+	requests   []string
+	windows    map[string][]time.Time
+	quotaDate  string
+	quotaCount int
+}
+
+// NewAPI returns a fake API handler without starting a server. Use it to compose
+// the fake with other handlers; most tests should use NewServer instead.
+func NewAPI(opts Options) *API {
+	if opts.APIKey == "" {
+		opts.APIKey = DefaultAPIKey
+	}
+	if opts.Now == nil {
+		opts.Now = time.Now
+	}
+
+	a := &API{
+		opts:      opts,
+		mux:       http.NewServeMux(),
+		jobs:      make(map[int]client.DetailedJob),
+		history:   make(map[int][]client.JobHistory),
+		nextJobID: 1,
+		nextLogID: 1,
+		windows:   make(map[string][]time.Time),
+	}
+
+	a.mux.HandleFunc("GET /jobs", a.listJobs)
+	a.mux.HandleFunc("PUT /jobs", a.createJob)
+	a.mux.HandleFunc("GET /jobs/{jobId}", a.getJob)
+	a.mux.HandleFunc("PATCH /jobs/{jobId}", a.updateJob)
+	a.mux.HandleFunc("DELETE /jobs/{jobId}", a.deleteJob)
+	a.mux.HandleFunc("GET /jobs/{jobId}/history", a.getHistory)
+	a.mux.HandleFunc("GET /jobs/{jobId}/history/{identifier}", a.getHistoryItem)
+
+	return a
+}
+
+// Server is a running fake API.
+type Server struct {
+	*httptest.Server
+	API *API
+}
+
+// NewServer starts a fake API server. Callers must Close it when done.
+func NewServer(opts Options) *Server {
+	api := NewAPI(opts)
+	return &Server{
+		Server: httptest.NewServer(api),
+		API:    api,
+	}
+}
+
+// APIKey returns the API key clients must send.
+func (a *API) APIKey() string {
+	return a.opts.APIKey
+}
+
+// Requests returns the requests served so far as "METHOD /path", including rejected ones.
+func (a *API) Requests() []string {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return append([]string(nil), a.requests...)
+}
+
+// ResetRequests clears the request log.
+func (a *API) ResetRequests() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.requests = nil
+}
+
+// ServeHTTP implements http.Handler.
+func (a *API) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	a.mu.Lock()
+	a.requests = append(a.requests, r.Method+" "+r.URL.Path)
+	a.mu.Unlock()
+
+	if r.Header.Get("Authorization") != "Bearer "+a.opts.APIKey {
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+	if retryAfter, limited := a.checkRateLimits(r); limited {
+		w.Header().Set("Retry-After", fmt.Sprintf("%d", int(math.Ceil(retryAfter.Seconds()))))
+		writeError(w, http.StatusTooManyRequests, "Too many requests")
+		return
+	}
+	if !a.checkQuota() {
+		writeError(w, http.StatusTooManyRequests, "Daily API request quota exceeded")
+		return
+	}
+
+	a.mux.ServeHTTP(w, r)
+}
+
+// checkRateLimits records the request in the sliding windows of the limits that apply to it.
+// If any limit is exceeded, the request is not recorded and the time until it would be
+// allowed is returned.
+func (a *API) checkRateLimits(r *http.Request) (time.Duration, bool) {
+	limits := map[string]RateLimit{"default": a.opts.RateLimit}
+	if r.Method == http.MethodPut && strings.TrimSuffix(r.URL.Path, "/") == "/jobs" {
+		limits["create"] = a.opts.CreateRateLimit
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	now := a.opts.Now()
+	var wait time.Duration
+	for name, limit := range limits {
+		if limit.Requests <= 0 {
+			continue
+		}
+		window := a.windows[name]
+		for len(window) > 0 && !window[0].After(now.Add(-limit.Period)) {
+			window = window[1:]
+		}
+		a.windows[name] = window
+		if len(window) >= limit.Requests {
+			if d := window[len(window)-limit.Requests].Add(limit.Period).Sub(now); d > wait {
+				wait = d
+			}
+		}
+	}
+	if wait > 0 {
+		return wait, true
+	}
+
+	for name, limit := range limits {
+		if limit.Requests > 0 {
+			a.windows[name] = append(a.windows[name], now)
+		}
+	}
+	return 0, false
+}
+
+// checkQuota counts the request against the daily quota and reports whether it is allowed.
+func (a *API) checkQuota() bool {
+	if a.opts.DailyQuota <= 0 {
+		return true
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	date := a.opts.Now().UTC().Format(time.DateOnly)
+	if date != a.quotaDate {
+		a.quotaDate = date
+		a.quotaCount = 0
+	}
+	if a.quotaCount >= a.opts.DailyQuota {
+		return false
+	}
+	a.quotaCount++
+	return true
+}
+
+// writeJSON writes v as a JSON response with the given status code.
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// writeError writes an error response in the format used by the API.
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]string{"error": message})
+}
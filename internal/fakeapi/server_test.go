@@ -0,0 +1,270 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package fakeapi
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/plain-insure/terraform-provider-cronjoborg/client"
+)
+
+// newTestClient returns a client for the server without client-side throttling or retries.
+func newTestClient(s *Server, apiKey string) *client.Client {
+	c := client.NewClient(s.URL, apiKey)
+	c.RateLimiter = nil
+	c.Retry.MaxAttempts = 1
+	return c
+}
+
+func TestServer_JobLifecycle(t *testing.T) {
+	s := NewServer(Options{})
+	defer s.Close()
+	c := newTestClient(s, DefaultAPIKey)
+	ctx := context.Background()
+
+	jobID, err := c.CreateJob(ctx, client.JobCreate{
+		Title:          "Backup",
+		URL:            "https://example.com/backup",
+		Enabled:        true,
+		RequestTimeout: 30,
+		Schedule: &client.JobSchedule{
+			Timezone: "Europe/Berlin",
+			Hours:    []int{3},
+			MDays:    []int{-1},
+			Minutes:  []int{15},
+			Months:   []int{-1},
+			WDays:    []int{-1},
+		},
+		ExtendedData: &client.JobExtendedData{Headers: map[string]string{"X-Token": "secret"}},
+	})
+	if err != nil {
+		t.Fatalf("CreateJob: %v", err)
+	}
+
+	job, err := c.GetJobDetails(ctx, "1")
+	if err != nil {
+		t.Fatalf("GetJobDetails: %v", err)
+	}
+	if job.JobID != jobID || job.Title != "Backup" || !job.Enabled || job.RequestTimeout != 30 {
+		t.Errorf("Unexpected job after create: %+v", job)
+	}
+	if job.Schedule.Timezone != "Europe/Berlin" || job.ExtendedData.Headers["X-Token"] != "secret" {
+		t.Errorf("Unexpected nested settings after create: %+v", job)
+	}
+
+	// A patch only changes the fields it contains; the header map is replaced as a whole.
+	title := "Nightly backup"
+	if err := c.UpdateJob(ctx, "1", client.JobPatch{
+		Title:        &title,
+		ExtendedData: &client.JobExtendedData{},
+	}); err != nil {
+		t.Fatalf("UpdateJob: %v", err)
+	}
+
+	job, err = c.GetJobDetails(ctx, "1")
+	if err != nil {
+		t.Fatalf("GetJobDetails: %v", err)
+	}
+	if job.Title != title || !job.Enabled || job.Schedule.Timezone != "Europe/Berlin" {
+		t.Errorf("Unexpected job after update: %+v", job)
+	}
+	if len(job.ExtendedData.Headers) != 0 {
+		t.Errorf("Expected headers to be removed, got %v", job.ExtendedData.Headers)
+	}
+
+	jobs, _, err := c.GetJobs(ctx)
+	if err != nil || len(jobs) != 1 || jobs[0].Title != title {
+		t.Fatalf("GetJobs: expected the updated job, got %+v, %v", jobs, err)
+	}
+
+	if err := c.DeleteJob(ctx, "1"); err != nil {
+		t.Fatalf("DeleteJob: %v", err)
+	}
+	if _, err := c.GetJobDetails(ctx, "1"); !errors.Is(err, client.ErrNotFound) {
+		t.Errorf("Expected ErrNotFound after delete, got %v", err)
+	}
+	if len(s.API.Jobs()) != 0 {
+		t.Errorf("Expected no jobs after delete, got %d", len(s.API.Jobs()))
+	}
+}
+
+func TestServer_RejectsInvalidRequests(t *testing.T) {
+	s := NewServer(Options{})
+	defer s.Close()
+	c := newTestClient(s, DefaultAPIKey)
+	ctx := context.Background()
+
+	if _, err := c.CreateJob(ctx, client.JobCreate{Title: "No URL"}); !errors.Is(err, client.ErrBadRequest) {
+		t.Errorf("Expected ErrBadRequest for a job without URL, got %v", err)
+	}
+	if _, err := c.CreateJob(ctx, client.JobCreate{
+		URL:      "https://example.com",
+		Schedule: &client.JobSchedule{Timezone: "UTC", Hours: []int{24}},
+	}); !errors.Is(err, client.ErrBadRequest) {
+		t.Errorf("Expected ErrBadRequest for an out-of-range hour, got %v", err)
+	}
+	title := "Missing"
+	if err := c.UpdateJob(ctx, "42", client.JobPatch{Title: &title}); !errors.Is(err, client.ErrNotFound) {
+		t.Errorf("Expected ErrNotFound for a missing job, got %v", err)
+	}
+}
+
+func TestServer_Auth(t *testing.T) {
+	s := NewServer(Options{APIKey: "right-key"})
+	defer s.Close()
+
+	_, _, err := newTestClient(s, "wrong-key").GetJobs(context.Background())
+	if !errors.Is(err, client.ErrUnauthorized) {
+		t.Errorf("Expected ErrUnauthorized, got %v", err)
+	}
+	if _, _, err := newTestClient(s, "right-key").GetJobs(context.Background()); err != nil {
+		t.Errorf("Expected no error with the right key, got %v", err)
+	}
+}
+
+func TestServer_RateLimits(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	s := NewServer(Options{
+		RateLimit:       RateLimit{Requests: 3, Period: time.Second},
+		CreateRateLimit: RateLimit{Requests: 1, Period: time.Minute},
+		Now:             func() time.Time { return now },
+	})
+	defer s.Close()
+	c := newTestClient(s, DefaultAPIKey)
+	ctx := context.Background()
+
+	if _, err := c.CreateJob(ctx, client.JobCreate{URL: "https://example.com"}); err != nil {
+		t.Fatalf("Expected first create to pass, got %v", err)
+	}
+	if _, err := c.CreateJob(ctx, client.JobCreate{URL: "https://example.com"}); !errors.Is(err, client.ErrRateLimited) {
+		t.Fatalf("Expected second create to be rate limited, got %v", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, _, err := c.GetJobs(ctx); err != nil {
+			t.Fatalf("Expected request %d to pass, got %v", i+2, err)
+		}
+	}
+	if _, _, err := c.GetJobs(ctx); !errors.Is(err, client.ErrRateLimited) {
+		t.Fatalf("Expected fourth request within a second to be rate limited, got %v", err)
+	}
+
+	now = now.Add(time.Second)
+	if _, _, err := c.GetJobs(ctx); err != nil {
+		t.Errorf("Expected request to pass after the window, got %v", err)
+	}
+}
+
+func TestServer_RateLimitRetryAfter(t *testing.T) {
+	s := NewServer(Options{RateLimit: RateLimit{Requests: 1, Period: time.Minute}})
+	defer s.Close()
+
+	for i, want := range []int{http.StatusOK, http.StatusTooManyRequests} {
+		req, _ := http.NewRequest(http.MethodGet, s.URL+"/jobs", nil)
+		req.Header.Set("Authorization", "Bearer "+DefaultAPIKey)
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != want {
+			t.Fatalf("request %d: expected status %d, got %d", i+1, want, resp.StatusCode)
+		}
+		if want == http.StatusTooManyRequests && resp.Header.Get("Retry-After") == "" {
+			t.Error("Expected a Retry-After header on 429 responses")
+		}
+	}
+}
+
+func TestServer_DailyQuota(t *testing.T) {
+	s := NewServer(Options{DailyQuota: 2})
+	defer s.Close()
+	c := newTestClient(s, DefaultAPIKey)
+
+	for i := 0; i < 2; i++ {
+		if _, _, err := c.GetJobs(context.Background()); err != nil {
+			t.Fatalf("Expected request %d to pass, got %v", i+1, err)
+		}
+	}
+	if _, _, err := c.GetJobs(context.Background()); !errors.Is(err, client.ErrQuotaExceeded) {
+		t.Errorf("Expected ErrQuotaExceeded, got %v", err)
+	}
+}
+
+func TestServer_History(t *testing.T) {
+	s := NewServer(Options{})
+	defer s.Close()
+	c := newTestClient(s, DefaultAPIKey)
+	ctx := context.Background()
+
+	jobID := s.API.AddJob(client.DetailedJob{Job: client.Job{Title: "Ping", URL: "https://example.com"}})
+	headers, body := "Content-Type: text/plain", "boom"
+	identifier, err := s.API.AddHistory(jobID, client.JobHistory{
+		Date:       1640189711,
+		Status:     client.JobStatusFailedHTTP,
+		StatusText: "HTTP error",
+		HttpStatus: 500,
+		Headers:    &headers,
+		Body:       &body,
+	})
+	if err != nil {
+		t.Fatalf("AddHistory: %v", err)
+	}
+	if !strings.HasPrefix(identifier, "1-") {
+		t.Errorf("Expected identifier to start with the job ID, got %q", identifier)
+	}
+
+	history, _, err := c.GetJobHistory(ctx, "1")
+	if err != nil || len(history) != 1 {
+		t.Fatalf("GetJobHistory: expected one item, got %+v, %v", history, err)
+	}
+	if history[0].Headers != nil || history[0].Body != nil {
+		t.Error("Expected the history list to omit headers and body")
+	}
+
+	item, err := c.GetJobHistoryItem(ctx, "1", identifier)
+	if err != nil {
+		t.Fatalf("GetJobHistoryItem: %v", err)
+	}
+	if item.Body == nil || *item.Body != body || item.Status != client.JobStatusFailedHTTP {
+		t.Errorf("Unexpected history item: %+v", item)
+	}
+
+	job, err := c.GetJob(ctx, "1")
+	if err != nil || job.LastStatus != client.JobStatusFailedHTTP {
+		t.Errorf("Expected last status to reflect the execution, got %+v, %v", job, err)
+	}
+
+	if _, err := c.GetJobHistoryItem(ctx, "1", "1-0-0-999"); !errors.Is(err, client.ErrNotFound) {
+		t.Errorf("Expected ErrNotFound for an unknown history item, got %v", err)
+	}
+}
+
+func TestServer_EmptyHeadersAsArray(t *testing.T) {
+	s := NewServer(Options{})
+	defer s.Close()
+	s.API.AddJob(defaultJob())
+
+	req, _ := http.NewRequest(http.MethodGet, s.URL+"/jobs/1", nil)
+	req.Header.Set("Authorization", "Bearer "+DefaultAPIKey)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	var buf strings.Builder
+	if _, err := io.Copy(&buf, resp.Body); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), `"headers":[]`) {
+		t.Errorf("Expected empty headers to be encoded as an array, got %s", buf.String())
+	}
+}
@@ -0,0 +1,235 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+// Package faultinject wraps an HTTP handler, such as the fake API, or proxies a
+// real server and injects scripted faults into selected requests: latency,
+// error responses, truncated bodies, dropped connections and rewritten JSON
+// responses that mimic schema quirks of the cron-job.org API.
+package faultinject
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/http/httputil"
+	"net/url"
+	"path"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Fault describes what happens to a request matched by a Rule. Faults are applied
+// in field order: the latency first, then either an injected response, a dropped
+// connection or the upstream response, which may be transformed and truncated.
+type Fault struct {
+	// Latency delays the request before it is handled.
+	Latency time.Duration
+
+	// StatusCode, if non-zero, answers the request with this status code without
+	// passing it upstream.
+	StatusCode int
+	// Body is the body of an injected response. Defaults to an API error object
+	// with the status text of StatusCode.
+	Body string
+	// RetryAfter sets the Retry-After header of an injected response.
+	RetryAfter time.Duration
+
+	// DropConnection closes the connection without sending a response and without
+	// passing the request upstream.
+	DropConnection bool
+	// DropAfterUpstream passes the request upstream before dropping the connection,
+	// so the server processes the request but the client never sees the response.
+	DropAfterUpstream bool
+
+	// Transform rewrites the upstream response body.
+	Transform func(body []byte) []byte
+	// TruncateBody, if positive, sends only this many bytes of the upstream response
+	// body while announcing the full Content-Length, so the client sees an unexpected EOF.
+	TruncateBody int
+}
+
+// Rule selects the requests a Fault applies to.
+type Rule struct {
+	// Method matches the request method. An empty Method matches any method.
+	Method string
+	// Path matches the request path using path.Match syntax, e.g. "/jobs/*".
+	// An empty Path matches any path.
+	Path string
+	// Skip lets this many matching requests through before the fault applies.
+	Skip int
+	// Times limits the fault to this many requests after Skip. Zero applies it
+	// to every matching request.
+	Times int
+	// Fault is applied to the selected requests.
+	Fault Fault
+}
+
+// matches reports whether the rule selects requests with the given method and path.
+func (r Rule) matches(method, p string) bool {
+	if r.Method != "" && r.Method != method {
+		return false
+	}
+	if r.Path == "" {
+		return true
+	}
+	ok, err := path.Match(r.Path, p)
+	return err == nil && ok
+}
+
+// rule is a Rule with the number of requests it has matched so far.
+type rule struct {
+	Rule
+	matched int
+}
+
+// Injector is an http.Handler that applies the first matching rule to each request
+// and passes everything else to the wrapped handler. It is safe for concurrent use.
+type Injector struct {
+	next http.Handler
+
+	mu       sync.Mutex
+	rules    []*rule
+	injected []string
+}
+
+// New returns an injector in front of next.
+func New(next http.Handler, rules ...Rule) *Injector {
+	i := &Injector{next: next}
+	i.Add(rules...)
+	return i
+}
+
+// NewProxy returns an injector in front of a reverse proxy to target, for injecting
+// faults into the traffic to a server that cannot be wrapped, such as the real API.
+func NewProxy(target *url.URL, rules ...Rule) *Injector {
+	return New(httputil.NewSingleHostReverseProxy(target), rules...)
+}
+
+// Add appends rules. Rules are evaluated in the order they were added.
+func (i *Injector) Add(rules ...Rule) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	for _, r := range rules {
+		i.rules = append(i.rules, &rule{Rule: r})
+	}
+}
+
+// Reset removes all rules and clears the log of injected faults.
+func (i *Injector) Reset() {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.rules = nil
+	i.injected = nil
+}
+
+// Injected returns the requests a fault was applied to so far as "METHOD /path".
+func (i *Injector) Injected() []string {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	return append([]string(nil), i.injected...)
+}
+
+// fault returns the fault for the request, if any rule selects it.
+func (i *Injector) fault(r *http.Request) (Fault, bool) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	for _, rl := range i.rules {
+		if !rl.matches(r.Method, r.URL.Path) {
+			continue
+		}
+		rl.matched++
+		if rl.matched <= rl.Skip {
+			continue
+		}
+		if rl.Times > 0 && rl.matched > rl.Skip+rl.Times {
+			continue
+		}
+		i.injected = append(i.injected, r.Method+" "+r.URL.Path)
+		return rl.Fault, true
+	}
+	return Fault{}, false
+}
+
+// ServeHTTP implements http.Handler.
+func (i *Injector) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f, ok := i.fault(r)
+	if !ok {
+		i.next.ServeHTTP(w, r)
+		return
+	}
+
+	if f.Latency > 0 {
+		timer := time.NewTimer(f.Latency)
+		select {
+		case <-r.Context().Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+	}
+
+	if f.StatusCode != 0 {
+		writeFault(w, f)
+		return
+	}
+	if f.DropConnection {
+		dropConnection(w)
+		return
+	}
+
+	// Capture the upstream response so it can be rewritten before it is sent.
+	rec := httptest.NewRecorder()
+	i.next.ServeHTTP(rec, r)
+	if f.DropAfterUpstream {
+		dropConnection(w)
+		return
+	}
+
+	body := rec.Body.Bytes()
+	if f.Transform != nil {
+		body = f.Transform(body)
+	}
+	for key, values := range rec.Header() {
+		w.Header()[key] = values
+	}
+	w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+	if f.TruncateBody > 0 && f.TruncateBody < len(body) {
+		body = body[:f.TruncateBody]
+	}
+	w.WriteHeader(rec.Code)
+	_, _ = w.Write(body)
+}
+
+// writeFault writes the injected response of f.
+func writeFault(w http.ResponseWriter, f Fault) {
+	body := f.Body
+	if body == "" {
+		body = fmt.Sprintf(`{"error":%q}`, http.StatusText(f.StatusCode))
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if f.RetryAfter > 0 {
+		w.Header().Set("Retry-After", strconv.Itoa(int((f.RetryAfter+time.Second-1)/time.Second)))
+	}
+	w.WriteHeader(f.StatusCode)
+	_, _ = w.Write([]byte(body))
+}
+
+// dropConnection closes the client connection without writing a response.
+func dropConnection(w http.ResponseWriter) {
+	conn, _, err := http.NewResponseController(w).Hijack()
+	if err != nil {
+		// Hijacking is not supported (e.g. HTTP/2); abort the response instead.
+		panic(http.ErrAbortHandler)
+	}
+	_ = conn.Close()
+}
+
+// ReplaceBody returns a transform that replaces the response body with body,
+// for example to simulate malformed JSON.
+func ReplaceBody(body string) func([]byte) []byte {
+	return func([]byte) []byte {
+		return []byte(body)
+	}
+}
@@ -0,0 +1,307 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package faultinject
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/plain-insure/terraform-provider-cronjoborg/client"
+	"github.com/plain-insure/terraform-provider-cronjoborg/internal/fakeapi"
+)
+
+// newFaultyAPI starts the fake API behind an injector with one job already created.
+func newFaultyAPI(t *testing.T, rules ...Rule) (*fakeapi.API, *Injector, *httptest.Server) {
+	t.Helper()
+
+	api := fakeapi.NewAPI(fakeapi.Options{})
+	api.AddJob(client.DetailedJob{Job: client.Job{Title: "Existing", URL: "https://example.com"}})
+	injector := New(api, rules...)
+	server := httptest.NewServer(injector)
+	t.Cleanup(server.Close)
+	return api, injector, server
+}
+
+// newTestClient returns a client without client-side throttling that retries quickly.
+func newTestClient(baseURL string) *client.Client {
+	c := client.NewClient(baseURL, fakeapi.DefaultAPIKey)
+	c.RateLimiter = nil
+	c.Retry.MinWait = time.Millisecond
+	c.Retry.MaxWait = 10 * time.Millisecond
+	return c
+}
+
+func TestInjector_RuleSelection(t *testing.T) {
+	injector := New(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}), Rule{
+		Method: http.MethodGet,
+		Path:   "/jobs/*",
+		Skip:   1,
+		Times:  2,
+		Fault:  Fault{StatusCode: http.StatusServiceUnavailable},
+	})
+
+	requests := []struct {
+		method, path string
+		expected     int
+	}{
+		{http.MethodGet, "/jobs/1", http.StatusOK},                 // skipped
+		{http.MethodGet, "/jobs/1", http.StatusServiceUnavailable}, // 1st
+		{http.MethodPatch, "/jobs/1", http.StatusOK},               // other method
+		{http.MethodGet, "/jobs", http.StatusOK},                   // other path
+		{http.MethodGet, "/jobs/2", http.StatusServiceUnavailable}, // 2nd
+		{http.MethodGet, "/jobs/1", http.StatusOK},                 // exhausted
+	}
+	for i, req := range requests {
+		rec := httptest.NewRecorder()
+		injector.ServeHTTP(rec, httptest.NewRequest(req.method, req.path, nil))
+		if rec.Code != req.expected {
+			t.Errorf("Request %d (%s %s): expected status %d, got %d", i+1, req.method, req.path, req.expected, rec.Code)
+		}
+	}
+
+	expected := []string{"GET /jobs/1", "GET /jobs/2"}
+	if got := injector.Injected(); strings.Join(got, ",") != strings.Join(expected, ",") {
+		t.Errorf("Expected injected faults %v, got %v", expected, got)
+	}
+
+	injector.Reset()
+	if len(injector.Injected()) != 0 {
+		t.Error("Expected Reset to clear the log of injected faults")
+	}
+}
+
+func TestInjector_ErrorBurstIsRetried(t *testing.T) {
+	_, injector, server := newFaultyAPI(t, Rule{
+		Path:  "/jobs/1",
+		Times: 3,
+		Fault: Fault{StatusCode: http.StatusServiceUnavailable},
+	})
+	c := newTestClient(server.URL)
+
+	job, err := c.GetJobDetails(context.Background(), "1")
+	if err != nil {
+		t.Fatalf("Expected the burst to be retried, got %v", err)
+	}
+	if job.Title != "Existing" {
+		t.Errorf("Expected job 'Existing', got %q", job.Title)
+	}
+	if n := len(injector.Injected()); n != 3 {
+		t.Errorf("Expected 3 injected errors, got %d", n)
+	}
+}
+
+func TestInjector_ErrorBurstExhaustsRetries(t *testing.T) {
+	_, _, server := newFaultyAPI(t, Rule{
+		Path:  "/jobs/1",
+		Fault: Fault{StatusCode: http.StatusBadGateway},
+	})
+	c := newTestClient(server.URL)
+
+	_, err := c.GetJobDetails(context.Background(), "1")
+	if !errors.Is(err, client.ErrServerError) {
+		t.Fatalf("Expected ErrServerError, got %v", err)
+	}
+}
+
+func TestInjector_RateLimitBeyondMaxWait(t *testing.T) {
+	_, injector, server := newFaultyAPI(t, Rule{
+		Fault: Fault{StatusCode: http.StatusTooManyRequests, RetryAfter: time.Minute},
+	})
+	c := newTestClient(server.URL)
+
+	_, _, err := c.GetJobs(context.Background())
+	if !errors.Is(err, client.ErrRateLimited) {
+		t.Fatalf("Expected ErrRateLimited, got %v", err)
+	}
+	if n := len(injector.Injected()); n != 1 {
+		t.Errorf("Expected a Retry-After above the maximum wait not to be retried, got %d attempts", n)
+	}
+}
+
+func TestInjector_DroppedConnection(t *testing.T) {
+	t.Run("idempotent request is retried", func(t *testing.T) {
+		_, _, server := newFaultyAPI(t, Rule{
+			Method: http.MethodGet,
+			Times:  1,
+			Fault:  Fault{DropConnection: true},
+		})
+		c := newTestClient(server.URL)
+
+		if _, err := c.GetJobDetails(context.Background(), "1"); err != nil {
+			t.Fatalf("Expected the dropped connection to be retried, got %v", err)
+		}
+	})
+
+	t.Run("create is not retried", func(t *testing.T) {
+		api, _, server := newFaultyAPI(t, Rule{
+			Method: http.MethodPut,
+			Times:  1,
+			Fault:  Fault{DropAfterUpstream: true},
+		})
+		c := newTestClient(server.URL)
+
+		_, err := c.CreateJob(context.Background(), client.JobCreate{Title: "Ambiguous", URL: "https://example.com"})
+		if err == nil {
+			t.Fatal("Expected an error for a create whose response was lost")
+		}
+		// The server processed the request, so retrying would have created a duplicate.
+		if n := len(api.Jobs()); n != 2 {
+			t.Errorf("Expected exactly one job to be created, got %d jobs", n)
+		}
+	})
+}
+
+func TestInjector_TruncatedBody(t *testing.T) {
+	_, injector, server := newFaultyAPI(t, Rule{
+		Method: http.MethodGet,
+		Path:   "/jobs/1",
+		Times:  1,
+		Fault:  Fault{TruncateBody: 20},
+	})
+	c := newTestClient(server.URL)
+
+	job, err := c.GetJobDetails(context.Background(), "1")
+	if err != nil {
+		t.Fatalf("Expected the truncated response to be retried, got %v", err)
+	}
+	if job.Title != "Existing" || len(injector.Injected()) != 1 {
+		t.Errorf("Expected job 'Existing' after one truncated response, got %q after %v", job.Title, injector.Injected())
+	}
+
+	c.Retry.MaxAttempts = 1
+	injector.Add(Rule{Fault: Fault{TruncateBody: 20}})
+	if _, err := c.GetJobDetails(context.Background(), "1"); err == nil || !strings.Contains(err.Error(), "failed to read response body") {
+		t.Errorf("Expected a body read error without retries, got %v", err)
+	}
+}
+
+func TestInjector_Latency(t *testing.T) {
+	_, _, server := newFaultyAPI(t, Rule{
+		Times: 1,
+		Fault: Fault{Latency: time.Second},
+	})
+	c := newTestClient(server.URL)
+	c.HTTPClient = &http.Client{Timeout: 50 * time.Millisecond}
+
+	start := time.Now()
+	if _, _, err := c.GetJobs(context.Background()); err != nil {
+		t.Fatalf("Expected the timed out request to be retried, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("Expected the slow request to time out, took %s", elapsed)
+	}
+}
+
+func TestInjector_SchemaQuirks(t *testing.T) {
+	testCases := []struct {
+		name  string
+		fault Fault
+		check func(t *testing.T, job *client.DetailedJob, err error)
+	}{
+		{
+			name:  "empty headers as array",
+			fault: Fault{Transform: EmptyObjectsAsArrays("headers")},
+			check: func(t *testing.T, job *client.DetailedJob, err error) {
+				if err != nil {
+					t.Fatal(err)
+				}
+				if job.ExtendedData.Headers == nil || len(job.ExtendedData.Headers) != 0 {
+					t.Errorf("Expected empty headers, got %#v", job.ExtendedData.Headers)
+				}
+			},
+		},
+		{
+			name:  "null next execution",
+			fault: Fault{Transform: NullFields("nextExecution")},
+			check: func(t *testing.T, job *client.DetailedJob, err error) {
+				if err != nil {
+					t.Fatal(err)
+				}
+				if job.NextExecution != nil {
+					t.Errorf("Expected no next execution, got %d", *job.NextExecution)
+				}
+			},
+		},
+		{
+			name:  "missing nested object",
+			fault: Fault{Transform: DropFields("notification", "extendedData")},
+			check: func(t *testing.T, job *client.DetailedJob, err error) {
+				if err != nil {
+					t.Fatal(err)
+				}
+				if job.Title != "Existing" || job.Notification.OnFailure {
+					t.Errorf("Unexpected job: %+v", job)
+				}
+			},
+		},
+		{
+			name:  "malformed JSON",
+			fault: Fault{Transform: ReplaceBody(`{"jobDetails": {`)},
+			check: func(t *testing.T, job *client.DetailedJob, err error) {
+				if err == nil || !strings.Contains(err.Error(), "failed to decode") {
+					t.Errorf("Expected a decode error, got %v", err)
+				}
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			api, _, server := newFaultyAPI(t, Rule{Path: "/jobs/*", Fault: tc.fault})
+			api.UpdateJob(1, func(job *client.DetailedJob) {
+				job.ExtendedData.Headers = map[string]string{}
+			})
+			c := newTestClient(server.URL)
+
+			job, err := c.GetJobDetails(context.Background(), "1")
+			tc.check(t, job, err)
+		})
+	}
+}
+
+func TestNewProxy(t *testing.T) {
+	api := fakeapi.NewServer(fakeapi.Options{})
+	defer api.Close()
+	target, err := url.Parse(api.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	proxy := httptest.NewServer(NewProxy(target, Rule{
+		Method: http.MethodGet,
+		Times:  1,
+		Fault:  Fault{StatusCode: http.StatusInternalServerError, Body: "upstream exploded"},
+	}))
+	defer proxy.Close()
+
+	req, err := http.NewRequest(http.MethodGet, proxy.URL+"/jobs", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Authorization", "Bearer "+api.API.APIKey())
+
+	for i, expected := range []int{http.StatusInternalServerError, http.StatusOK} {
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if resp.StatusCode != expected {
+			t.Errorf("Request %d: expected status %d, got %d: %s", i+1, expected, resp.StatusCode, body)
+		}
+	}
+	if n := len(api.API.Requests()); n != 1 {
+		t.Errorf("Expected only the second request to reach the API, got %d", n)
+	}
+}
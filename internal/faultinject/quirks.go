@@ -0,0 +1,105 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package faultinject
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// EmptyObjectsAsArrays returns a transform that encodes empty objects stored under
+// the given keys as empty arrays, anywhere in the response. This is how the API's
+// PHP backend encodes empty maps such as extendedData.headers. Without keys, every
+// empty object is rewritten.
+func EmptyObjectsAsArrays(keys ...string) func([]byte) []byte {
+	return rewriteJSON(keys, func(v interface{}) (interface{}, bool) {
+		if m, ok := v.(map[string]interface{}); ok && len(m) == 0 {
+			return []interface{}{}, true
+		}
+		return v, false
+	})
+}
+
+// NullFields returns a transform that sets the fields with the given keys to null,
+// anywhere in the response.
+func NullFields(keys ...string) func([]byte) []byte {
+	return rewriteJSON(keys, func(interface{}) (interface{}, bool) {
+		return nil, true
+	})
+}
+
+// DropFields returns a transform that removes the fields with the given keys,
+// anywhere in the response.
+func DropFields(keys ...string) func([]byte) []byte {
+	drop := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		drop[key] = true
+	}
+	return transformJSON(func(v interface{}) interface{} {
+		return walk(v, func(key string, value interface{}) (interface{}, bool, bool) {
+			return value, false, drop[key]
+		})
+	})
+}
+
+// rewriteJSON returns a transform that passes the values stored under keys (or under
+// any key, if keys is empty) to fn and replaces them when fn reports a change.
+func rewriteJSON(keys []string, fn func(v interface{}) (interface{}, bool)) func([]byte) []byte {
+	selected := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		selected[key] = true
+	}
+	return transformJSON(func(v interface{}) interface{} {
+		return walk(v, func(key string, value interface{}) (interface{}, bool, bool) {
+			if len(selected) > 0 && !selected[key] {
+				return value, false, false
+			}
+			replacement, changed := fn(value)
+			return replacement, changed, false
+		})
+	})
+}
+
+// walk visits every object field of v depth-first. visit returns the new value of the
+// field, whether it replaced the value (its children are then not visited) and
+// whether the field should be removed.
+func walk(v interface{}, visit func(key string, value interface{}) (interface{}, bool, bool)) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for key, value := range v {
+			replacement, replaced, remove := visit(key, value)
+			switch {
+			case remove:
+				delete(v, key)
+			case replaced:
+				v[key] = replacement
+			default:
+				v[key] = walk(value, visit)
+			}
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = walk(item, visit)
+		}
+	}
+	return v
+}
+
+// transformJSON adapts a function on decoded JSON documents to a body transform.
+// Bodies that are not valid JSON are passed through unchanged.
+func transformJSON(fn func(v interface{}) interface{}) func([]byte) []byte {
+	return func(body []byte) []byte {
+		dec := json.NewDecoder(bytes.NewReader(body))
+		dec.UseNumber()
+		var v interface{}
+		if err := dec.Decode(&v); err != nil {
+			return body
+		}
+		out, err := json.Marshal(fn(v))
+		if err != nil {
+			return body
+		}
+		return out
+	}
+}
@@ -0,0 +1,63 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package faultinject
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestTransforms(t *testing.T) {
+	input := `{"jobDetails":{"jobId":1,"nextExecution":1700000000,"auth":{},"extendedData":{"headers":{},"body":""},"schedule":{"hours":[-1]}}}`
+
+	testCases := []struct {
+		name      string
+		transform func([]byte) []byte
+		expected  string
+	}{
+		{
+			name:      "empty objects as arrays",
+			transform: EmptyObjectsAsArrays("headers"),
+			expected:  `{"jobDetails":{"jobId":1,"nextExecution":1700000000,"auth":{},"extendedData":{"headers":[],"body":""},"schedule":{"hours":[-1]}}}`,
+		},
+		{
+			name:      "all empty objects as arrays",
+			transform: EmptyObjectsAsArrays(),
+			expected:  `{"jobDetails":{"jobId":1,"nextExecution":1700000000,"auth":[],"extendedData":{"headers":[],"body":""},"schedule":{"hours":[-1]}}}`,
+		},
+		{
+			name:      "null fields",
+			transform: NullFields("nextExecution", "hours"),
+			expected:  `{"jobDetails":{"jobId":1,"nextExecution":null,"auth":{},"extendedData":{"headers":{},"body":""},"schedule":{"hours":null}}}`,
+		},
+		{
+			name:      "drop fields",
+			transform: DropFields("auth", "body"),
+			expected:  `{"jobDetails":{"jobId":1,"nextExecution":1700000000,"extendedData":{"headers":{}},"schedule":{"hours":[-1]}}}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var got, expected interface{}
+			if err := json.Unmarshal(tc.transform([]byte(input)), &got); err != nil {
+				t.Fatalf("Transform produced invalid JSON: %v", err)
+			}
+			if err := json.Unmarshal([]byte(tc.expected), &expected); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, expected) {
+				t.Errorf("Expected %s, got %s", tc.expected, tc.transform([]byte(input)))
+			}
+		})
+	}
+}
+
+func TestTransforms_InvalidJSON(t *testing.T) {
+	body := []byte(`{"truncated":`)
+	if got := NullFields("truncated")(body); string(got) != string(body) {
+		t.Errorf("Expected invalid JSON to pass through unchanged, got %s", got)
+	}
+}
@@ -0,0 +1,300 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+// Package recorder implements an HTTP record/replay layer for acceptance tests.
+// In record mode, requests are sent to the real API and each request/response pair
+// is stored in a cassette file with credentials redacted. In replay mode, responses
+// are served from the cassette without any network access, and a request that does
+// not match the next recorded one fails the test.
+package recorder
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/plain-insure/terraform-provider-cronjoborg/internal/redact"
+)
+
+// EnvMode is the environment variable that selects the recorder mode for acceptance tests.
+const EnvMode = "CRONJOBORG_RECORDER_MODE"
+
+// Mode selects how a Recorder handles requests.
+type Mode string
+
+const (
+	// ModeOff sends requests to the API without recording them.
+	ModeOff Mode = ""
+	// ModeRecord sends requests to the API and records them in the cassette.
+	ModeRecord Mode = "record"
+	// ModeReplay serves responses from the cassette without network access.
+	ModeReplay Mode = "replay"
+)
+
+// ModeFromEnv returns the mode selected by EnvMode.
+func ModeFromEnv() (Mode, error) {
+	switch mode := Mode(strings.ToLower(strings.TrimSpace(os.Getenv(EnvMode)))); mode {
+	case ModeOff, ModeRecord, ModeReplay:
+		return mode, nil
+	default:
+		return ModeOff, fmt.Errorf("invalid %s %q: expected %q or %q", EnvMode, mode, ModeRecord, ModeReplay)
+	}
+}
+
+// ErrDiverged is returned in replay mode when a request does not match the cassette.
+var ErrDiverged = errors.New("request sequence diverges from cassette")
+
+// Recorder is an http.RoundTripper that records or replays API interactions.
+// It is safe for concurrent use, but replay expects requests in recorded order.
+type Recorder struct {
+	mode      Mode
+	path      string
+	transport http.RoundTripper
+	secrets   []string
+	headers   *redact.Redactor
+
+	mu       sync.Mutex
+	cassette *Cassette
+	next     int
+	err      error
+}
+
+// New returns a recorder for the cassette at path. In replay mode the cassette must exist.
+// secrets, typically the API key, are replaced with redact.Redacted wherever they appear in
+// recorded requests and responses; the Authorization header is always redacted.
+func New(mode Mode, path string, transport http.RoundTripper, secrets ...string) (*Recorder, error) {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	r := &Recorder{
+		mode:      mode,
+		path:      path,
+		transport: transport,
+		cassette:  &Cassette{},
+	}
+	for _, secret := range secrets {
+		if secret != "" {
+			r.secrets = append(r.secrets, secret)
+		}
+	}
+
+	if mode == ModeReplay {
+		cassette, err := LoadCassette(path)
+		if err != nil {
+			return nil, err
+		}
+		r.cassette = cassette
+	}
+
+	return r, nil
+}
+
+// RedactHeaders replaces the values of the given job request headers in recorded
+// bodies with redact.Redacted. Values that differ between runs, such as idempotency keys,
+// must be redacted for replayed requests to match the cassette. It must be called
+// before the first request.
+func (r *Recorder) RedactHeaders(names ...string) {
+	r.headers = redact.Headers(names...)
+}
+
+// Mode returns the mode of the recorder.
+func (r *Recorder) Mode() Mode {
+	return r.mode
+}
+
+// RoundTrip implements http.RoundTripper.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	switch r.mode {
+	case ModeRecord:
+		return r.record(req)
+	case ModeReplay:
+		return r.replay(req)
+	default:
+		return r.transport.RoundTrip(req)
+	}
+}
+
+// Err returns the first replay divergence, if any.
+func (r *Recorder) Err() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.err
+}
+
+// Stop finishes the recording. In record mode it writes the cassette; in replay mode it
+// reports an error if a request diverged or recorded interactions were not replayed.
+func (r *Recorder) Stop() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	switch r.mode {
+	case ModeRecord:
+		return r.cassette.Save(r.path)
+	case ModeReplay:
+		if r.err != nil {
+			return r.err
+		}
+		if remaining := len(r.cassette.Interactions) - r.next; remaining > 0 {
+			next := r.cassette.Interactions[r.next].Request
+			return fmt.Errorf("%w: %d recorded interactions were not replayed, starting with %s %s", ErrDiverged, remaining, next.Method, next.Path)
+		}
+	}
+	return nil
+}
+
+func (r *Recorder) record(req *http.Request) (*http.Response, error) {
+	reqBody, err := readBody(req.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	// RoundTrippers must not modify the caller's request, so send a copy with a fresh body.
+	out := req.Clone(req.Context())
+	if req.Body != nil {
+		out.Body = io.NopCloser(strings.NewReader(reqBody))
+	}
+
+	resp, err := r.transport.RoundTrip(out)
+	if err != nil {
+		// Transport errors are not recorded; replaying them would not be meaningful.
+		return nil, err
+	}
+	respBody, err := readBody(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(strings.NewReader(respBody))
+
+	interaction := Interaction{
+		Request: RecordedRequest{
+			Method:  req.Method,
+			Path:    req.URL.RequestURI(),
+			Headers: r.redactHeader(req.Header),
+			Body:    r.redactBody(reqBody),
+		},
+		Response: RecordedResponse{
+			StatusCode: resp.StatusCode,
+			Headers:    r.redactHeader(resp.Header),
+			Body:       r.redactBody(respBody),
+		},
+	}
+
+	r.mu.Lock()
+	r.cassette.Interactions = append(r.cassette.Interactions, interaction)
+	r.mu.Unlock()
+
+	return resp, nil
+}
+
+func (r *Recorder) replay(req *http.Request) (*http.Response, error) {
+	reqBody, err := readBody(req.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.err != nil {
+		return nil, r.err
+	}
+
+	got := fmt.Sprintf("%s %s", req.Method, req.URL.RequestURI())
+	if r.next >= len(r.cassette.Interactions) {
+		r.err = fmt.Errorf("%w: request %d (%s) was not recorded", ErrDiverged, r.next+1, got)
+		return nil, r.err
+	}
+
+	interaction := r.cassette.Interactions[r.next]
+	want := fmt.Sprintf("%s %s", interaction.Request.Method, interaction.Request.Path)
+	if got != want {
+		r.err = fmt.Errorf("%w: request %d is %s, recorded %s", ErrDiverged, r.next+1, got, want)
+		return nil, r.err
+	}
+	if !equalBodies(r.redactBody(reqBody), interaction.Request.Body) {
+		r.err = fmt.Errorf("%w: request %d (%s) has body %s, recorded %s", ErrDiverged, r.next+1, got, r.redactBody(reqBody), interaction.Request.Body)
+		return nil, r.err
+	}
+	r.next++
+
+	header := interaction.Response.Headers.Clone()
+	if header == nil {
+		header = http.Header{}
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", interaction.Response.StatusCode, http.StatusText(interaction.Response.StatusCode)),
+		StatusCode:    interaction.Response.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(strings.NewReader(interaction.Response.Body)),
+		ContentLength: int64(len(interaction.Response.Body)),
+		Request:       req,
+	}, nil
+}
+
+// redact replaces all secrets in s.
+func (r *Recorder) redact(s string) string {
+	for _, secret := range r.secrets {
+		s = strings.ReplaceAll(s, secret, redact.Redacted)
+	}
+	return s
+}
+
+// redactBody replaces all secrets and the values of the headers passed to RedactHeaders in body.
+func (r *Recorder) redactBody(body string) string {
+	if r.headers != nil {
+		body = string(r.headers.JSON([]byte(body)))
+	}
+	return r.redact(body)
+}
+
+// redactHeader returns a copy of h with the Authorization header and all secrets redacted.
+func (r *Recorder) redactHeader(h http.Header) http.Header {
+	if len(h) == 0 {
+		return nil
+	}
+	redacted := make(http.Header, len(h))
+	for key, values := range h {
+		for _, v := range values {
+			if http.CanonicalHeaderKey(key) == "Authorization" {
+				v = "Bearer " + redact.Redacted
+			}
+			redacted.Add(key, r.redact(v))
+		}
+	}
+	return redacted
+}
+
+// readBody reads and closes a request or response body.
+func readBody(body io.ReadCloser) (string, error) {
+	if body == nil || body == http.NoBody {
+		return "", nil
+	}
+	defer body.Close()
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return "", fmt.Errorf("recorder: failed to read body: %w", err)
+	}
+	return string(data), nil
+}
+
+// equalBodies compares two bodies, ignoring JSON formatting and key order.
+func equalBodies(a, b string) bool {
+	if a == b {
+		return true
+	}
+	var va, vb interface{}
+	if json.Unmarshal([]byte(a), &va) != nil || json.Unmarshal([]byte(b), &vb) != nil {
+		return false
+	}
+	return reflect.DeepEqual(va, vb)
+}
@@ -0,0 +1,249 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package recorder
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/plain-insure/terraform-provider-cronjoborg/client"
+	"github.com/plain-insure/terraform-provider-cronjoborg/internal/fakeapi"
+	"github.com/plain-insure/terraform-provider-cronjoborg/internal/redact"
+)
+
+const testAPIKey = "super-secret-key"
+
+// newTestClient returns a client that sends its requests through the recorder.
+func newTestClient(baseURL string, rec *Recorder) *client.Client {
+	c := client.NewClient(baseURL, testAPIKey)
+	c.HTTPClient = &http.Client{Transport: rec}
+	c.RateLimiter = nil
+	c.Retry.MaxAttempts = 1
+	return c
+}
+
+// recordSession records a create, read and delete of a job against the fake API.
+func recordSession(t *testing.T, path string) {
+	t.Helper()
+
+	server := fakeapi.NewServer(fakeapi.Options{APIKey: testAPIKey})
+	defer server.Close()
+
+	rec, err := New(ModeRecord, path, nil, testAPIKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := newTestClient(server.URL, rec)
+	ctx := context.Background()
+
+	jobID, err := c.CreateJob(ctx, client.JobCreate{Title: "Recorded", URL: "https://example.com/?key=" + testAPIKey})
+	if err != nil {
+		t.Fatalf("CreateJob: %v", err)
+	}
+	if jobID != 1 {
+		t.Fatalf("Expected job ID 1, got %d", jobID)
+	}
+	if _, err := c.GetJobDetails(ctx, "1"); err != nil {
+		t.Fatalf("GetJobDetails: %v", err)
+	}
+	if err := c.DeleteJob(ctx, "1"); err != nil {
+		t.Fatalf("DeleteJob: %v", err)
+	}
+
+	if err := rec.Stop(); err != nil {
+		t.Fatalf("Stop: %v", err)
+	}
+}
+
+func TestRecorder_RecordAndReplay(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cassettes", "session.json")
+	recordSession(t, path)
+
+	cassette, err := LoadCassette(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cassette.Interactions) != 3 {
+		t.Fatalf("Expected 3 recorded interactions, got %d", len(cassette.Interactions))
+	}
+
+	rec, err := New(ModeReplay, path, nil, testAPIKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The server is gone: replay must not need the network.
+	c := newTestClient("http://127.0.0.1:1", rec)
+	ctx := context.Background()
+
+	jobID, err := c.CreateJob(ctx, client.JobCreate{Title: "Recorded", URL: "https://example.com/?key=" + testAPIKey})
+	if err != nil || jobID != 1 {
+		t.Fatalf("CreateJob replay: expected job 1, got %d, %v", jobID, err)
+	}
+	job, err := c.GetJobDetails(ctx, "1")
+	if err != nil {
+		t.Fatalf("GetJobDetails replay: %v", err)
+	}
+	if job.Title != "Recorded" {
+		t.Errorf("Expected replayed title 'Recorded', got %q", job.Title)
+	}
+	if err := c.DeleteJob(ctx, "1"); err != nil {
+		t.Fatalf("DeleteJob replay: %v", err)
+	}
+
+	if err := rec.Stop(); err != nil {
+		t.Errorf("Expected replay to complete, got %v", err)
+	}
+}
+
+func TestRecorder_RedactsAPIKey(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "session.json")
+	recordSession(t, path)
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), testAPIKey) {
+		t.Errorf("Expected the API key to be redacted from the cassette:\n%s", data)
+	}
+	if !strings.Contains(string(data), "Bearer "+redact.Redacted) {
+		t.Errorf("Expected the Authorization header to be redacted:\n%s", data)
+	}
+}
+
+func TestRecorder_ReplayDivergence(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "session.json")
+	recordSession(t, path)
+	ctx := context.Background()
+
+	testCases := []struct {
+		name string
+		run  func(c *client.Client) error
+	}{
+		{
+			name: "different request",
+			run: func(c *client.Client) error {
+				_, _, err := c.GetJobs(ctx)
+				return err
+			},
+		},
+		{
+			name: "different body",
+			run: func(c *client.Client) error {
+				_, err := c.CreateJob(ctx, client.JobCreate{Title: "Other", URL: "https://example.com"})
+				return err
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			rec, err := New(ModeReplay, path, nil, testAPIKey)
+			if err != nil {
+				t.Fatal(err)
+			}
+			c := newTestClient("http://127.0.0.1:1", rec)
+
+			if err := tc.run(c); !errors.Is(err, ErrDiverged) {
+				t.Fatalf("Expected ErrDiverged, got %v", err)
+			}
+			if !errors.Is(rec.Stop(), ErrDiverged) {
+				t.Error("Expected Stop to report the divergence")
+			}
+		})
+	}
+}
+
+func TestRecorder_ReplayIncomplete(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "session.json")
+	recordSession(t, path)
+
+	rec, err := New(ModeReplay, path, nil, testAPIKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := newTestClient("http://127.0.0.1:1", rec)
+	if _, err := c.CreateJob(context.Background(), client.JobCreate{Title: "Recorded", URL: "https://example.com/?key=" + testAPIKey}); err != nil {
+		t.Fatalf("CreateJob replay: %v", err)
+	}
+
+	err = rec.Stop()
+	if !errors.Is(err, ErrDiverged) || !strings.Contains(err.Error(), "2 recorded interactions were not replayed") {
+		t.Errorf("Expected Stop to report unplayed interactions, got %v", err)
+	}
+}
+
+func TestRecorder_MissingCassette(t *testing.T) {
+	if _, err := New(ModeReplay, filepath.Join(t.TempDir(), "missing.json"), nil); err == nil {
+		t.Error("Expected an error when replaying a missing cassette")
+	}
+}
+
+func TestModeFromEnv(t *testing.T) {
+	for value, expected := range map[string]Mode{"": ModeOff, "record": ModeRecord, " Replay ": ModeReplay} {
+		t.Setenv(EnvMode, value)
+		mode, err := ModeFromEnv()
+		if err != nil || mode != expected {
+			t.Errorf("%s=%q: expected %q, got %q, %v", EnvMode, value, expected, mode, err)
+		}
+	}
+
+	t.Setenv(EnvMode, "rewind")
+	if _, err := ModeFromEnv(); err == nil {
+		t.Error("Expected an error for an unknown mode")
+	}
+}
+
+func TestRecorder_RedactHeaders(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "session.json")
+	server := fakeapi.NewServer(fakeapi.Options{APIKey: testAPIKey})
+	defer server.Close()
+
+	create := func(c *client.Client, key string) error {
+		_, err := c.CreateJob(context.Background(), client.JobCreate{
+			Title:        "Keyed",
+			URL:          "https://example.com",
+			ExtendedData: &client.JobExtendedData{Headers: map[string]string{"X-Key": key}},
+		})
+		return err
+	}
+
+	rec, err := New(ModeRecord, path, nil, testAPIKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rec.RedactHeaders("X-Key")
+	if err := create(newTestClient(server.URL, rec), "first-run"); err != nil {
+		t.Fatal(err)
+	}
+	if err := rec.Stop(); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "first-run") {
+		t.Errorf("Expected the header value to be redacted from the cassette:\n%s", data)
+	}
+
+	// A request that differs only in the redacted header replays.
+	rec, err = New(ModeReplay, path, nil, testAPIKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rec.RedactHeaders("X-Key")
+	if err := create(newTestClient("http://127.0.0.1:1", rec), "second-run"); err != nil {
+		t.Fatalf("Expected the request to replay, got %v", err)
+	}
+	if err := rec.Stop(); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,140 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+// Package redact removes credentials from cron-job.org API requests and responses
+// before they are logged or stored: the API key in the Authorization header, HTTP
+// basic auth passwords of jobs and the values of sensitive job request headers.
+package redact
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+// Redacted replaces redacted values.
+const Redacted = "REDACTED"
+
+// DefaultSensitiveHeaders are the HTTP headers whose values are always redacted, both
+// in requests to the API and in the request headers of jobs.
+var DefaultSensitiveHeaders = []string{
+	"Authorization",
+	"Cookie",
+	"Proxy-Authorization",
+	"Set-Cookie",
+	"X-Api-Key",
+	"X-Auth-Token",
+}
+
+// Redactor redacts credentials. A nil Redactor redacts DefaultSensitiveHeaders only.
+type Redactor struct {
+	sensitive map[string]bool
+	passwords bool
+}
+
+// New returns a Redactor that redacts DefaultSensitiveHeaders and the given headers.
+// Header names are matched case-insensitively.
+func New(sensitiveHeaders ...string) *Redactor {
+	r := Headers(append(append([]string(nil), DefaultSensitiveHeaders...), sensitiveHeaders...)...)
+	r.passwords = true
+	return r
+}
+
+// Headers returns a Redactor that redacts only the given headers, neither
+// DefaultSensitiveHeaders nor passwords. It suits values that are not secret but
+// must be masked, for example because they differ between otherwise equal requests.
+func Headers(names ...string) *Redactor {
+	r := &Redactor{sensitive: make(map[string]bool, len(names))}
+	for _, name := range names {
+		r.sensitive[http.CanonicalHeaderKey(strings.TrimSpace(name))] = true
+	}
+	return r
+}
+
+// IsSensitiveHeader reports whether the value of the header name is redacted.
+func (r *Redactor) IsSensitiveHeader(name string) bool {
+	if r == nil {
+		r = defaultRedactor
+	}
+	return r.sensitive[http.CanonicalHeaderKey(name)]
+}
+
+var defaultRedactor = New()
+
+// Header returns a copy of h with the values of sensitive headers redacted.
+func (r *Redactor) Header(h http.Header) http.Header {
+	if h == nil {
+		return nil
+	}
+	redacted := make(http.Header, len(h))
+	for key, values := range h {
+		if r.IsSensitiveHeader(key) {
+			values = []string{Redacted}
+		}
+		redacted[key] = append([]string(nil), values...)
+	}
+	return redacted
+}
+
+// JSON returns body with the password of every "auth" object (unless created by
+// Headers) and the values of sensitive headers in every "headers" object replaced
+// with Redacted. This covers jobs in request payloads as well as in responses. A
+// body that is not valid JSON is returned unchanged, except that an empty body
+// stays empty.
+func (r *Redactor) JSON(body []byte) []byte {
+	if r == nil {
+		r = defaultRedactor
+	}
+	if len(bytes.TrimSpace(body)) == 0 {
+		return body
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	var v interface{}
+	if err := decoder.Decode(&v); err != nil {
+		return body
+	}
+
+	if !r.walk(v, "") {
+		return body
+	}
+	redacted, err := json.Marshal(v)
+	if err != nil {
+		return body
+	}
+	return redacted
+}
+
+// walk redacts v in place and reports whether anything was redacted. parent is the
+// key under which v is stored in its enclosing object.
+func (r *Redactor) walk(v interface{}, parent string) bool {
+	changed := false
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for key, value := range v {
+			switch {
+			case r.passwords && parent == "auth" && key == "password":
+				if s, ok := value.(string); ok && s != "" {
+					v[key] = Redacted
+					changed = true
+				}
+			case parent == "headers" && r.IsSensitiveHeader(key):
+				v[key] = Redacted
+				changed = true
+			default:
+				if r.walk(value, key) {
+					changed = true
+				}
+			}
+		}
+	case []interface{}:
+		for _, value := range v {
+			if r.walk(value, parent) {
+				changed = true
+			}
+		}
+	}
+	return changed
+}
@@ -0,0 +1,112 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package redact
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestRedactor_Header(t *testing.T) {
+	h := http.Header{
+		"Authorization": {"Bearer secret-key"},
+		"Content-Type":  {"application/json"},
+		"X-Custom":      {"custom-secret"},
+	}
+
+	got := New("x-custom").Header(h)
+	want := http.Header{
+		"Authorization": {Redacted},
+		"Content-Type":  {"application/json"},
+		"X-Custom":      {Redacted},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Header() = %v, want %v", got, want)
+	}
+	if h.Get("Authorization") != "Bearer secret-key" {
+		t.Error("Expected the original header not to be modified")
+	}
+
+	var r *Redactor
+	if got := r.Header(h); got.Get("Authorization") != Redacted || got.Get("X-Custom") != "custom-secret" {
+		t.Errorf("Expected a nil Redactor to redact the default headers only, got %v", got)
+	}
+}
+
+func TestRedactor_JSON(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want string
+	}{
+		{
+			name: "job payload",
+			body: `{"job": {"url": "https://example.com", "requestTimeout": 30, "auth": {"enable": true, "user": "admin", "password": "hunter2"}, "extendedData": {"headers": {"X-Custom": "custom-secret", "Authorization": "Bearer token", "Accept": "text/plain"}, "body": "payload"}}}`,
+			want: `{"job": {"url": "https://example.com", "requestTimeout": 30, "auth": {"enable": true, "user": "admin", "password": "REDACTED"}, "extendedData": {"headers": {"X-Custom": "REDACTED", "Authorization": "REDACTED", "Accept": "text/plain"}, "body": "payload"}}}`,
+		},
+		{
+			name: "job list",
+			body: `{"jobs": [{"jobId": 1, "auth": {"password": "a"}}, {"jobId": 2, "auth": {"password": ""}}], "someFailed": false}`,
+			want: `{"jobs": [{"jobId": 1, "auth": {"password": "REDACTED"}}, {"jobId": 2, "auth": {"password": ""}}], "someFailed": false}`,
+		},
+		{
+			name: "password outside auth",
+			body: `{"title": "password", "password": "kept"}`,
+			want: `{"title": "password", "password": "kept"}`,
+		},
+		{
+			name: "empty headers encoded as array",
+			body: `{"extendedData": {"headers": []}}`,
+			want: `{"extendedData": {"headers": []}}`,
+		},
+		{
+			name: "large number",
+			body: `{"jobId": 12345678901234567890, "auth": {"password": "x"}}`,
+			want: `{"jobId": 12345678901234567890, "auth": {"password": "REDACTED"}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := New("x-custom").JSON([]byte(tt.body))
+			if !equalJSON(t, got, []byte(tt.want)) {
+				t.Errorf("JSON() = %s, want %s", got, tt.want)
+			}
+			if tt.name == "large number" && !strings.Contains(string(got), "12345678901234567890") {
+				t.Errorf("Expected numbers to be preserved, got %s", got)
+			}
+		})
+	}
+}
+
+func TestRedactor_JSONInvalid(t *testing.T) {
+	for _, body := range []string{"", "not json", `{"auth": {"password": "x"}`} {
+		if got := New().JSON([]byte(body)); string(got) != body {
+			t.Errorf("JSON(%q) = %q, want the body unchanged", body, got)
+		}
+	}
+}
+
+func equalJSON(t *testing.T, a, b []byte) bool {
+	t.Helper()
+	var va, vb interface{}
+	if err := json.Unmarshal(a, &va); err != nil {
+		t.Fatalf("invalid JSON %s: %v", a, err)
+	}
+	if err := json.Unmarshal(b, &vb); err != nil {
+		t.Fatalf("invalid JSON %s: %v", b, err)
+	}
+	return reflect.DeepEqual(va, vb)
+}
+
+func TestHeaders(t *testing.T) {
+	body := `{"auth": {"password": "kept"}, "extendedData": {"headers": {"Authorization": "kept", "X-Key": "123"}}}`
+	want := `{"auth": {"password": "kept"}, "extendedData": {"headers": {"Authorization": "kept", "X-Key": "REDACTED"}}}`
+	if got := Headers("x-key").JSON([]byte(body)); !equalJSON(t, got, []byte(want)) {
+		t.Errorf("JSON() = %s, want %s", got, want)
+	}
+}
@@ -0,0 +1,99 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+// Package telemetry configures OpenTelemetry tracing for the provider process from
+// the standard OTEL_* environment variables. Tracing is off unless an OTLP endpoint
+// is configured or OTEL_TRACES_EXPORTER is set to "otlp".
+package telemetry
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+)
+
+// ServiceName is the default service.name of the provider's spans. It can be
+// overridden with OTEL_SERVICE_NAME or OTEL_RESOURCE_ATTRIBUTES.
+const ServiceName = "terraform-provider-cronjoborg"
+
+// Enabled reports whether the environment asks for traces to be exported.
+func Enabled() (bool, error) {
+	if disabled, _ := strconv.ParseBool(os.Getenv("OTEL_SDK_DISABLED")); disabled {
+		return false, nil
+	}
+
+	switch exporter := strings.TrimSpace(os.Getenv("OTEL_TRACES_EXPORTER")); exporter {
+	case "":
+		return os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT") != "" || os.Getenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT") != "", nil
+	case "otlp":
+		return true, nil
+	case "none":
+		return false, nil
+	default:
+		return false, fmt.Errorf("unsupported OTEL_TRACES_EXPORTER %q: expected \"otlp\" or \"none\"", exporter)
+	}
+}
+
+// Setup installs a global TracerProvider that exports spans over OTLP if Enabled,
+// and the W3C trace context propagator. The exporter reads its endpoint, headers and
+// TLS settings from the OTEL_EXPORTER_OTLP_* variables. The returned function flushes
+// pending spans and must be called before the process exits; it is a no-op if
+// tracing is disabled.
+func Setup(ctx context.Context, version string) (shutdown func(context.Context) error, err error) {
+	noop := func(context.Context) error { return nil }
+
+	enabled, err := Enabled()
+	if err != nil || !enabled {
+		return noop, err
+	}
+
+	exporter, err := newExporter(ctx)
+	if err != nil {
+		return noop, err
+	}
+
+	res, err := resource.New(ctx,
+		resource.WithAttributes(semconv.ServiceName(ServiceName), semconv.ServiceVersion(version)),
+		resource.WithTelemetrySDK(),
+		resource.WithFromEnv(),
+	)
+	if err != nil {
+		return noop, fmt.Errorf("failed to create OpenTelemetry resource: %w", err)
+	}
+
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+	)
+	otel.SetTracerProvider(tp)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	return tp.Shutdown, nil
+}
+
+// newExporter returns the OTLP exporter for the protocol selected by
+// OTEL_EXPORTER_OTLP_TRACES_PROTOCOL or OTEL_EXPORTER_OTLP_PROTOCOL.
+func newExporter(ctx context.Context) (sdktrace.SpanExporter, error) {
+	protocol := os.Getenv("OTEL_EXPORTER_OTLP_TRACES_PROTOCOL")
+	if protocol == "" {
+		protocol = os.Getenv("OTEL_EXPORTER_OTLP_PROTOCOL")
+	}
+
+	switch protocol {
+	case "", "http/protobuf":
+		return otlptracehttp.New(ctx)
+	case "grpc":
+		return otlptracegrpc.New(ctx)
+	default:
+		return nil, fmt.Errorf("unsupported OTLP protocol %q: expected \"http/protobuf\" or \"grpc\"", protocol)
+	}
+}
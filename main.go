@@ -4,9 +4,12 @@
 package main
 
 import (
+	"context"
 	"flag"
+	"log"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/plugin"
+	"github.com/plain-insure/terraform-provider-cronjoborg/internal/telemetry"
 	"github.com/plain-insure/terraform-provider-cronjoborg/provider"
 )
 
@@ -16,7 +19,7 @@ import (
 var (
 	// these will be set by the goreleaser configuration
 	// to appropriate values for the compiled binary.
-	version string = "dev" //nolint:unused // Set by build via ldflags
+	version string = "dev"
 
 	// goreleaser can pass other information to the main package, such as the specific commit
 	// https://goreleaser.com/cookbooks/using-main.version/
@@ -28,8 +31,19 @@ func main() {
 	flag.BoolVar(&debugMode, "debug", false, "set to true to run the provider with support for debuggers like delve")
 	flag.Parse()
 
+	// Tracing is configured through the standard OTEL_* environment variables.
+	shutdownTracing, err := telemetry.Setup(context.Background(), version)
+	if err != nil {
+		log.Printf("[WARN] OpenTelemetry tracing is disabled: %s", err)
+	}
+	defer func() {
+		if err := shutdownTracing(context.Background()); err != nil {
+			log.Printf("[WARN] failed to flush OpenTelemetry spans: %s", err)
+		}
+	}()
+
 	opts := &plugin.ServeOpts{
-		ProviderFunc: provider.Provider,
+		ProviderFunc: provider.New(version),
 		// TODO: update this string with the full name of your provider as used in your configs
 		ProviderAddr: "registry.terraform.io/plain-insure/cronjoborg",
 		Debug:        debugMode,
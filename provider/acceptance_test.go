@@ -11,7 +11,7 @@ import (
 )
 
 func TestAccJobDataSource(t *testing.T) {
-	resource.Test(t, resource.TestCase{
+	testAccRun(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
 		ProviderFactories: testAccProviderFactories,
 		Steps: []resource.TestStep{
@@ -29,7 +29,7 @@ func TestAccJobDataSource(t *testing.T) {
 }
 
 func TestAccJobsDataSource(t *testing.T) {
-	resource.Test(t, resource.TestCase{
+	testAccRun(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
 		ProviderFactories: testAccProviderFactories,
 		Steps: []resource.TestStep{
@@ -44,7 +44,7 @@ func TestAccJobsDataSource(t *testing.T) {
 }
 
 func TestAccJobHistoryDataSource(t *testing.T) {
-	resource.Test(t, resource.TestCase{
+	testAccRun(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
 		ProviderFactories: testAccProviderFactories,
 		Steps: []resource.TestStep{
@@ -0,0 +1,57 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/plain-insure/terraform-provider-cronjoborg/client"
+)
+
+// withBudgetWarnings wraps the CRUD functions of r so that the operation whose
+// requests cross request_budget_warning_threshold reports a warning.
+func withBudgetWarnings(r *schema.Resource) {
+	if create := r.CreateContext; create != nil {
+		r.CreateContext = func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+			return append(create(ctx, d, m), budgetWarningDiags(m)...)
+		}
+	}
+	if read := r.ReadContext; read != nil {
+		r.ReadContext = func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+			return append(read(ctx, d, m), budgetWarningDiags(m)...)
+		}
+	}
+	if update := r.UpdateContext; update != nil {
+		r.UpdateContext = func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+			return append(update(ctx, d, m), budgetWarningDiags(m)...)
+		}
+	}
+	if del := r.DeleteContext; del != nil {
+		r.DeleteContext = func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+			return append(del(ctx, d, m), budgetWarningDiags(m)...)
+		}
+	}
+}
+
+// budgetWarningDiags returns a warning if the client's request budget crossed its
+// warning threshold since the last call.
+func budgetWarningDiags(m interface{}) diag.Diagnostics {
+	c, ok := m.(*client.Client)
+	if !ok {
+		return nil
+	}
+	warning, ok := c.Quota.TakeWarning()
+	if !ok {
+		return nil
+	}
+	return diag.Diagnostics{{
+		Severity: diag.Warning,
+		Summary:  "API request budget almost used up",
+		Detail: fmt.Sprintf("%s. Requests fail once all %d requests have been used; the budget resets at midnight UTC.",
+			warning, warning.DailyLimit),
+	}}
+}
@@ -0,0 +1,123 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/plain-insure/terraform-provider-cronjoborg/client"
+)
+
+// Values of the on_conflict argument of cronjoborg_job.
+const (
+	onConflictCreate = "create"
+	onConflictAdopt  = "adopt"
+	onConflictError  = "error"
+)
+
+// Values of the conflict_match argument of cronjoborg_job.
+const (
+	conflictMatchTitleAndURL = "title_and_url"
+	conflictMatchTitle       = "title"
+	conflictMatchURL         = "url"
+)
+
+// conflictMatches reports whether an existing job conflicts with job under the given
+// conflict_match setting.
+func conflictMatches(existing client.Job, job client.JobCreate, match string) bool {
+	switch match {
+	case conflictMatchTitle:
+		return existing.Title == job.Title
+	case conflictMatchURL:
+		return existing.URL == job.URL
+	default:
+		return existing.Title == job.Title && existing.URL == job.URL
+	}
+}
+
+// conflictingJobs returns the existing jobs that conflict with job. It fails if the
+// job list is incomplete, since a missing job could be a conflict.
+func conflictingJobs(ctx context.Context, c client.JobsAPI, job client.JobCreate, match string) ([]client.Job, diag.Diagnostics) {
+	jobs, incomplete, err := c.GetJobs(ctx)
+	if err != nil {
+		return nil, apiErrorDiag("Error listing cron jobs to check for conflicts", err)
+	}
+	if incomplete {
+		return nil, diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  "Incomplete list of cron jobs",
+			Detail: "The cron-job.org API could not retrieve some jobs due to internal errors, so the provider " +
+				"cannot tell whether the job conflicts with an existing one. Try again later, or set on_conflict = \"create\".",
+		}}
+	}
+
+	var conflicts []client.Job
+	for _, existing := range jobs {
+		if conflictMatches(existing, job, match) {
+			conflicts = append(conflicts, existing)
+		}
+	}
+	return conflicts, nil
+}
+
+// describeJobs lists jobs for a diagnostic, e.g. `job 12 ("Backup", https://example.com)`.
+func describeJobs(jobs []client.Job) string {
+	descriptions := make([]string, len(jobs))
+	for i, job := range jobs {
+		descriptions[i] = fmt.Sprintf("job %d (%q, %s)", job.JobID, job.Title, job.URL)
+	}
+	return strings.Join(descriptions, ", ")
+}
+
+// resolveConflict applies the on_conflict setting before a job is created. It returns
+// the ID of the existing job to adopt, or "" if the job should be created.
+func resolveConflict(ctx context.Context, c client.JobsAPI, d *schema.ResourceData, job client.JobCreate) (string, diag.Diagnostics) {
+	onConflict, ok := d.Get("on_conflict").(string)
+	if !ok {
+		return "", diag.Errorf("on_conflict must be a string")
+	}
+	if onConflict == onConflictCreate {
+		return "", nil
+	}
+	match, ok := d.Get("conflict_match").(string)
+	if !ok {
+		return "", diag.Errorf("conflict_match must be a string")
+	}
+
+	conflicts, diags := conflictingJobs(ctx, c, job, match)
+	if diags.HasError() || len(conflicts) == 0 {
+		return "", diags
+	}
+
+	if onConflict == onConflictError {
+		return "", diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  "Cron job already exists",
+			Detail: fmt.Sprintf("The job conflicts with %s. Import it with `terraform import`, set on_conflict = \"adopt\" "+
+				"to manage it with this resource, or set on_conflict = \"create\" to create another job.", describeJobs(conflicts)),
+		}}
+	}
+	if len(conflicts) > 1 {
+		return "", diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  "Cannot adopt an existing cron job",
+			Detail: fmt.Sprintf("The job conflicts with several existing jobs: %s. Import the intended job with `terraform import`, "+
+				"or change conflict_match to tell them apart.", describeJobs(conflicts)),
+		}}
+	}
+	return strconv.Itoa(conflicts[0].JobID), nil
+}
+
+// adoptJob takes over an existing job by overwriting its settings with the configured job.
+func adoptJob(ctx context.Context, c client.JobsAPI, jobID string, job client.JobCreate) diag.Diagnostics {
+	if err := c.UpdateJob(ctx, jobID, job.Patch()); err != nil {
+		return apiErrorDiag(fmt.Sprintf("Error adopting cron job %s", jobID), err)
+	}
+	return nil
+}
@@ -0,0 +1,160 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/plain-insure/terraform-provider-cronjoborg/client"
+	"github.com/plain-insure/terraform-provider-cronjoborg/client/clienttest"
+)
+
+// testMockConflictAPI returns a mock API that lists jobs and stores patches of them.
+func testMockConflictAPI(jobs ...client.Job) (*clienttest.MockJobsAPI, map[string]client.JobPatch) {
+	patches := map[string]client.JobPatch{}
+	return &clienttest.MockJobsAPI{
+		GetJobsFunc: func(ctx context.Context) ([]client.Job, bool, error) {
+			return jobs, false, nil
+		},
+		UpdateJobFunc: func(ctx context.Context, jobID string, patch client.JobPatch) error {
+			patches[jobID] = patch
+			return nil
+		},
+		GetJobDetailsFunc: func(ctx context.Context, jobID string) (*client.DetailedJob, error) {
+			return testMockJob(), nil
+		},
+	}, patches
+}
+
+// testConflictConfig returns testMockJobConfig with the given on_conflict settings.
+func testConflictConfig(onConflict, match string) map[string]interface{} {
+	config := map[string]interface{}{"on_conflict": onConflict}
+	for k, v := range testMockJobConfig {
+		config[k] = v
+	}
+	if match != "" {
+		config["conflict_match"] = match
+	}
+	return config
+}
+
+func TestResourceJobCreate_OnConflictCreate(t *testing.T) {
+	api := &clienttest.MockJobsAPI{
+		CreateJobFunc: func(ctx context.Context, job client.JobCreate) (int, error) {
+			return 1, nil
+		},
+		GetJobDetailsFunc: func(ctx context.Context, jobID string) (*client.DetailedJob, error) {
+			return testMockJob(), nil
+		},
+	}
+	d := schema.TestResourceDataRaw(t, resourceJob().Schema, testMockJobConfig)
+
+	if diags := resourceJobCreate(context.Background(), d, api); diags.HasError() {
+		t.Fatalf("Create failed: %v", diags)
+	}
+	// The default does not look for existing jobs.
+	if got, want := api.Calls(), []string{"CreateJob", "GetJobDetails 1"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("calls = %v, want %v", got, want)
+	}
+}
+
+func TestResourceJobCreate_OnConflictAdopt(t *testing.T) {
+	existing := testMockJob().Job
+	existing.JobID = 7
+	other := testMockJob().Job
+	other.JobID = 8
+	other.URL = "https://example.com/other"
+
+	api, patches := testMockConflictAPI(other, existing)
+	d := schema.TestResourceDataRaw(t, resourceJob().Schema, testConflictConfig(onConflictAdopt, ""))
+
+	if diags := resourceJobCreate(context.Background(), d, api); diags.HasError() {
+		t.Fatalf("Create failed: %v", diags)
+	}
+	if d.Id() != "7" {
+		t.Errorf("expected the existing job 7 to be adopted, got %q", d.Id())
+	}
+	if got, want := api.Calls(), []string{"GetJobs", "UpdateJob 7", "GetJobDetails 7"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("calls = %v, want %v", got, want)
+	}
+
+	patch := patches["7"]
+	if patch.Title == nil || *patch.Title != "Backup" || patch.ExtendedData == nil || patch.Schedule == nil {
+		t.Errorf("expected the adopted job to be overwritten with the configuration, got %+v", patch)
+	}
+}
+
+func TestResourceJobCreate_OnConflictAdoptAmbiguous(t *testing.T) {
+	first := testMockJob().Job
+	second := testMockJob().Job
+	second.JobID = 2
+	second.Title = "Another backup"
+
+	api, _ := testMockConflictAPI(first, second)
+	d := schema.TestResourceDataRaw(t, resourceJob().Schema, testConflictConfig(onConflictAdopt, conflictMatchURL))
+
+	diags := resourceJobCreate(context.Background(), d, api)
+	if !diags.HasError() {
+		t.Fatal("expected an error")
+	}
+	if !strings.Contains(diags[0].Detail, "job 1 (") || !strings.Contains(diags[0].Detail, "job 2 (") {
+		t.Errorf("expected the detail to name both jobs, got %q", diags[0].Detail)
+	}
+	if got, want := api.Calls(), []string{"GetJobs"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("calls = %v, want %v", got, want)
+	}
+}
+
+func TestResourceJobCreate_OnConflictError(t *testing.T) {
+	existing := testMockJob().Job
+	existing.JobID = 7
+	existing.URL = "https://example.com/elsewhere"
+
+	tests := []struct {
+		name      string
+		match     string
+		wantError bool
+	}{
+		{"title and url", conflictMatchTitleAndURL, false},
+		{"title", conflictMatchTitle, true},
+		{"url", conflictMatchURL, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api, _ := testMockConflictAPI(existing)
+			api.CreateJobFunc = func(ctx context.Context, job client.JobCreate) (int, error) {
+				return 1, nil
+			}
+			d := schema.TestResourceDataRaw(t, resourceJob().Schema, testConflictConfig(onConflictError, tt.match))
+
+			diags := resourceJobCreate(context.Background(), d, api)
+			if diags.HasError() != tt.wantError {
+				t.Fatalf("HasError() = %t, want %t: %v", diags.HasError(), tt.wantError, diags)
+			}
+			if tt.wantError && !strings.Contains(diags[0].Detail, `job 7 ("Backup", https://example.com/elsewhere)`) {
+				t.Errorf("expected the detail to name the conflicting job, got %q", diags[0].Detail)
+			}
+		})
+	}
+}
+
+func TestResourceJobCreate_OnConflictIncompleteList(t *testing.T) {
+	api := &clienttest.MockJobsAPI{
+		GetJobsFunc: func(ctx context.Context) ([]client.Job, bool, error) {
+			return nil, true, nil
+		},
+	}
+	d := schema.TestResourceDataRaw(t, resourceJob().Schema, testConflictConfig(onConflictAdopt, ""))
+
+	if diags := resourceJobCreate(context.Background(), d, api); !diags.HasError() {
+		t.Fatal("expected an error for an incomplete job list")
+	}
+	if got, want := api.Calls(), []string{"GetJobs"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("calls = %v, want %v", got, want)
+	}
+}
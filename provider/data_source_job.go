@@ -5,6 +5,7 @@ package provider
 
 import (
 	"context"
+	"fmt"
 	"strconv"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -45,7 +46,7 @@ func dataSourceJob() *schema.Resource {
 			"last_status": {
 				Type:        schema.TypeInt,
 				Computed:    true,
-				Description: "Last execution status",
+				Description: jobStatusDescription,
 			},
 			"last_duration": {
 				Type:        schema.TypeInt,
@@ -65,7 +66,7 @@ func dataSourceJob() *schema.Resource {
 			"type": {
 				Type:        schema.TypeInt,
 				Computed:    true,
-				Description: "Job type (0=Default job, 1=Monitoring job)",
+				Description: jobTypeDescription,
 			},
 			"request_timeout": {
 				Type:        schema.TypeInt,
@@ -85,7 +86,7 @@ func dataSourceJob() *schema.Resource {
 			"request_method": {
 				Type:        schema.TypeInt,
 				Computed:    true,
-				Description: "HTTP request method",
+				Description: requestMethodDescription,
 			},
 			"schedule": {
 				Type:        schema.TypeList,
@@ -222,9 +223,9 @@ func dataSourceJob() *schema.Resource {
 }
 
 func dataSourceJobRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c, ok := m.(*client.Client)
+	c, ok := m.(client.JobsAPI)
 	if !ok {
-		return diag.Errorf("expected *client.Client, got %T", m)
+		return diag.Errorf("expected client.JobsAPI, got %T", m)
 	}
 
 	jobIDVal, ok := d.Get("job_id").(int)
@@ -233,10 +234,13 @@ func dataSourceJobRead(ctx context.Context, d *schema.ResourceData, m interface{
 	}
 	jobIDStr := strconv.Itoa(jobIDVal)
 
-	job, err := c.GetJob(jobIDStr)
+	// The details include auth, notification and extendedData in addition to the
+	// fields returned by the job list, so a single request covers all attributes.
+	detailedJob, err := c.GetJobDetails(ctx, jobIDStr)
 	if err != nil {
-		return diag.FromErr(err)
+		return apiErrorDiag(fmt.Sprintf("Error reading cron job %s", jobIDStr), err)
 	}
+	job := &detailedJob.Job
 
 	d.SetId(jobIDStr)
 
@@ -253,7 +257,7 @@ func dataSourceJobRead(ctx context.Context, d *schema.ResourceData, m interface{
 	if err := d.Set("url", job.URL); err != nil {
 		return diag.FromErr(err)
 	}
-	if err := d.Set("last_status", job.LastStatus); err != nil {
+	if err := d.Set("last_status", int(job.LastStatus)); err != nil {
 		return diag.FromErr(err)
 	}
 	if err := d.Set("last_duration", job.LastDuration); err != nil {
@@ -267,7 +271,7 @@ func dataSourceJobRead(ctx context.Context, d *schema.ResourceData, m interface{
 			return diag.FromErr(err)
 		}
 	}
-	if err := d.Set("type", job.Type); err != nil {
+	if err := d.Set("type", int(job.Type)); err != nil {
 		return diag.FromErr(err)
 	}
 	if err := d.Set("request_timeout", job.RequestTimeout); err != nil {
@@ -279,13 +283,7 @@ func dataSourceJobRead(ctx context.Context, d *schema.ResourceData, m interface{
 	if err := d.Set("folder_id", job.FolderID); err != nil {
 		return diag.FromErr(err)
 	}
-	if err := d.Set("request_method", job.RequestMethod); err != nil {
-		return diag.FromErr(err)
-	}
-
-	// For data sources, we need to get the detailed job to access auth, notification, and extendedData
-	detailedJob, err := c.GetJobDetails(jobIDStr)
-	if err != nil {
+	if err := d.Set("request_method", int(job.RequestMethod)); err != nil {
 		return diag.FromErr(err)
 	}
 
@@ -332,7 +330,7 @@ func dataSourceJobRead(ctx context.Context, d *schema.ResourceData, m interface{
 	// Set extended_data
 	extendedData := []interface{}{
 		map[string]interface{}{
-			"headers": detailedJob.ExtendedData.Headers,
+			"headers": withoutIdempotencyKey(detailedJob.ExtendedData.Headers),
 			"body":    detailedJob.ExtendedData.Body,
 		},
 	}
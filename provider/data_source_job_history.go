@@ -80,7 +80,7 @@ func dataSourceJobHistory() *schema.Resource {
 						"status": {
 							Type:        schema.TypeInt,
 							Computed:    true,
-							Description: "Status of execution",
+							Description: enumDescription("Status of execution", client.JobStatuses(), client.JobStatus.Description),
 						},
 						"status_text": {
 							Type:        schema.TypeString,
@@ -102,45 +102,7 @@ func dataSourceJobHistory() *schema.Resource {
 							Computed:    true,
 							Description: "Raw response body returned by the host",
 						},
-						"stats": {
-							Type:        schema.TypeList,
-							Computed:    true,
-							Description: "Additional timing information for this request",
-							Elem: &schema.Resource{
-								Schema: map[string]*schema.Schema{
-									"name_lookup": {
-										Type:        schema.TypeInt,
-										Computed:    true,
-										Description: "Time from transfer start until name lookups completed (in microseconds)",
-									},
-									"connect": {
-										Type:        schema.TypeInt,
-										Computed:    true,
-										Description: "Time from transfer start until socket connect completed (in microseconds)",
-									},
-									"app_connect": {
-										Type:        schema.TypeInt,
-										Computed:    true,
-										Description: "Time from transfer start until SSL handshake completed (in microseconds)",
-									},
-									"pre_transfer": {
-										Type:        schema.TypeInt,
-										Computed:    true,
-										Description: "Time from transfer start until beginning of data transfer (in microseconds)",
-									},
-									"start_transfer": {
-										Type:        schema.TypeInt,
-										Computed:    true,
-										Description: "Time from transfer start until the first response byte is received (in microseconds)",
-									},
-									"total": {
-										Type:        schema.TypeInt,
-										Computed:    true,
-										Description: "Total transfer time (in microseconds)",
-									},
-								},
-							},
-						},
+						"stats": historyItemStatsSchema(),
 					},
 				},
 			},
@@ -148,10 +110,67 @@ func dataSourceJobHistory() *schema.Resource {
 	}
 }
 
+// historyItemStatsSchema returns the schema of the timing information of a history item.
+func historyItemStatsSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Computed:    true,
+		Description: "Additional timing information for this request",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"name_lookup": {
+					Type:        schema.TypeInt,
+					Computed:    true,
+					Description: "Time from transfer start until name lookups completed (in microseconds)",
+				},
+				"connect": {
+					Type:        schema.TypeInt,
+					Computed:    true,
+					Description: "Time from transfer start until socket connect completed (in microseconds)",
+				},
+				"app_connect": {
+					Type:        schema.TypeInt,
+					Computed:    true,
+					Description: "Time from transfer start until SSL handshake completed (in microseconds)",
+				},
+				"pre_transfer": {
+					Type:        schema.TypeInt,
+					Computed:    true,
+					Description: "Time from transfer start until beginning of data transfer (in microseconds)",
+				},
+				"start_transfer": {
+					Type:        schema.TypeInt,
+					Computed:    true,
+					Description: "Time from transfer start until the first response byte is received (in microseconds)",
+				},
+				"total": {
+					Type:        schema.TypeInt,
+					Computed:    true,
+					Description: "Total transfer time (in microseconds)",
+				},
+			},
+		},
+	}
+}
+
+// flattenHistoryItemStats converts the timing information of a history item to its schema representation.
+func flattenHistoryItemStats(stats client.JobHistoryItemStats) []interface{} {
+	return []interface{}{
+		map[string]interface{}{
+			"name_lookup":    stats.NameLookup,
+			"connect":        stats.Connect,
+			"app_connect":    stats.AppConnect,
+			"pre_transfer":   stats.PreTransfer,
+			"start_transfer": stats.StartTransfer,
+			"total":          stats.Total,
+		},
+	}
+}
+
 func dataSourceJobHistoryRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c, ok := m.(*client.Client)
+	c, ok := m.(client.JobsAPI)
 	if !ok {
-		return diag.Errorf("expected *client.Client, got %T", m)
+		return diag.Errorf("expected client.JobsAPI, got %T", m)
 	}
 
 	jobIDVal, ok := d.Get("job_id").(int)
@@ -160,9 +179,9 @@ func dataSourceJobHistoryRead(ctx context.Context, d *schema.ResourceData, m int
 	}
 	jobIDStr := strconv.Itoa(jobIDVal)
 
-	history, predictions, err := c.GetJobHistory(jobIDStr)
+	history, predictions, err := c.GetJobHistory(ctx, jobIDStr)
 	if err != nil {
-		return diag.FromErr(err)
+		return apiErrorDiag(fmt.Sprintf("Error reading history of cron job %s", jobIDStr), err)
 	}
 
 	// Set a composite ID based on the job ID and number of history entries
@@ -176,17 +195,6 @@ func dataSourceJobHistoryRead(ctx context.Context, d *schema.ResourceData, m int
 	// Convert history to the expected format
 	historyList := make([]interface{}, len(history))
 	for i, entry := range history {
-		stats := []interface{}{
-			map[string]interface{}{
-				"name_lookup":    entry.Stats.NameLookup,
-				"connect":        entry.Stats.Connect,
-				"app_connect":    entry.Stats.AppConnect,
-				"pre_transfer":   entry.Stats.PreTransfer,
-				"start_transfer": entry.Stats.StartTransfer,
-				"total":          entry.Stats.Total,
-			},
-		}
-
 		historyMap := map[string]interface{}{
 			"job_log_id":   entry.JobLogID,
 			"job_id":       entry.JobID,
@@ -196,10 +204,10 @@ func dataSourceJobHistoryRead(ctx context.Context, d *schema.ResourceData, m int
 			"jitter":       entry.Jitter,
 			"url":          entry.URL,
 			"duration":     entry.Duration,
-			"status":       entry.Status,
+			"status":       int(entry.Status),
 			"status_text":  entry.StatusText,
 			"http_status":  entry.HttpStatus,
-			"stats":        stats,
+			"stats":        flattenHistoryItemStats(entry.Stats),
 		}
 
 		// Handle nullable headers and body
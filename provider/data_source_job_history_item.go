@@ -0,0 +1,154 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/plain-insure/terraform-provider-cronjoborg/client"
+)
+
+func dataSourceJobHistoryItem() *schema.Resource {
+	return &schema.Resource{
+		Description: "Fetch the details of a single execution of a cron job, including the response headers and body returned by the host.",
+		ReadContext: dataSourceJobHistoryItemRead,
+		Schema: map[string]*schema.Schema{
+			"job_id": {
+				Type:         schema.TypeInt,
+				Required:     true,
+				Description:  "The unique identifier of the job",
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"identifier": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "Identifier of the history item, as returned in the `history` list of the `cronjoborg_job_history` data source",
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+			"job_log_id": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The unique identifier of the history log entry",
+			},
+			"date": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Unix timestamp of the actual execution",
+			},
+			"date_planned": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Unix timestamp of the planned execution",
+			},
+			"jitter": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Scheduling jitter in milliseconds",
+			},
+			"url": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Job URL at time of execution",
+			},
+			"duration": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The execution duration in milliseconds",
+			},
+			"status": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: enumDescription("Status of execution", client.JobStatuses(), client.JobStatus.Description),
+			},
+			"status_text": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Detailed job status description",
+			},
+			"failed": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the execution failed",
+			},
+			"http_status": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The HTTP status code returned",
+			},
+			"headers": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Raw response headers returned by the host",
+			},
+			"body": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Raw response body returned by the host",
+			},
+			"stats": historyItemStatsSchema(),
+		},
+	}
+}
+
+func dataSourceJobHistoryItemRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c, ok := m.(client.JobsAPI)
+	if !ok {
+		return diag.Errorf("expected client.JobsAPI, got %T", m)
+	}
+
+	jobIDVal, ok := d.Get("job_id").(int)
+	if !ok {
+		return diag.Errorf("job_id must be an integer")
+	}
+	jobIDStr := strconv.Itoa(jobIDVal)
+
+	identifier, ok := d.Get("identifier").(string)
+	if !ok {
+		return diag.Errorf("identifier must be a string")
+	}
+
+	item, err := c.GetJobHistoryItem(ctx, jobIDStr, identifier)
+	if err != nil {
+		return apiErrorDiag(fmt.Sprintf("Error reading history item %s of cron job %s", identifier, jobIDStr), err)
+	}
+
+	d.SetId(fmt.Sprintf("job-%s-history-%s", jobIDStr, identifier))
+
+	values := map[string]interface{}{
+		"job_log_id":   item.JobLogID,
+		"date":         item.Date,
+		"date_planned": item.DatePlanned,
+		"jitter":       item.Jitter,
+		"url":          item.URL,
+		"duration":     item.Duration,
+		"status":       int(item.Status),
+		"status_text":  item.StatusText,
+		"failed":       item.Status.IsFailure(),
+		"http_status":  item.HttpStatus,
+		"headers":      "",
+		"body":         "",
+		"stats":        flattenHistoryItemStats(item.Stats),
+	}
+
+	// Handle nullable headers and body
+	if item.Headers != nil {
+		values["headers"] = *item.Headers
+	}
+	if item.Body != nil {
+		values["body"] = *item.Body
+	}
+
+	for key, value := range values {
+		if err := d.Set(key, value); err != nil {
+			return diag.Errorf("error setting %s: %s", key, err)
+		}
+	}
+
+	return nil
+}
@@ -17,10 +17,16 @@ func dataSourceJobs() *schema.Resource {
 		Description: "Fetch information about all cron jobs in your account.",
 		ReadContext: dataSourceJobsRead,
 		Schema: map[string]*schema.Schema{
+			"fail_on_incomplete": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Fail instead of warning when the list is still incomplete after the retries configured by the provider's `incomplete_list_retries`.",
+			},
 			"some_failed": {
 				Type:        schema.TypeBool,
 				Computed:    true,
-				Description: "True if some jobs could not be retrieved due to internal errors",
+				Description: "True if some jobs could not be retrieved due to internal errors, so `jobs` is incomplete",
 			},
 			"jobs": {
 				Type:        schema.TypeList,
@@ -56,7 +62,7 @@ func dataSourceJobs() *schema.Resource {
 						"last_status": {
 							Type:        schema.TypeInt,
 							Computed:    true,
-							Description: "Last execution status",
+							Description: jobStatusDescription,
 						},
 						"last_duration": {
 							Type:        schema.TypeInt,
@@ -76,7 +82,7 @@ func dataSourceJobs() *schema.Resource {
 						"type": {
 							Type:        schema.TypeInt,
 							Computed:    true,
-							Description: "Job type (0=Default job, 1=Monitoring job)",
+							Description: jobTypeDescription,
 						},
 						"request_timeout": {
 							Type:        schema.TypeInt,
@@ -96,7 +102,7 @@ func dataSourceJobs() *schema.Resource {
 						"request_method": {
 							Type:        schema.TypeInt,
 							Computed:    true,
-							Description: "HTTP request method",
+							Description: requestMethodDescription,
 						},
 						"schedule": {
 							Type:        schema.TypeList,
@@ -165,22 +171,43 @@ func dataSourceJobs() *schema.Resource {
 }
 
 func dataSourceJobsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c, ok := m.(*client.Client)
+	c, ok := m.(client.JobsAPI)
+	if !ok {
+		return diag.Errorf("expected client.JobsAPI, got %T", m)
+	}
+
+	failOnIncomplete, ok := d.Get("fail_on_incomplete").(bool)
 	if !ok {
-		return diag.Errorf("expected *client.Client, got %T", m)
+		return diag.Errorf("fail_on_incomplete must be a boolean")
 	}
 
-	jobs, err := c.GetJobs()
+	jobs, incomplete, err := c.GetJobs(ctx)
 	if err != nil {
-		return diag.FromErr(err)
+		return apiErrorDiag("Error listing cron jobs", err)
+	}
+
+	var diags diag.Diagnostics
+	if incomplete {
+		detail := fmt.Sprintf("The cron-job.org API could not retrieve some jobs due to internal errors, even after "+
+			"repeating the listing incomplete_list_retries times. The list contains only the %d jobs that were returned.", len(jobs))
+		if failOnIncomplete {
+			return diag.Diagnostics{{
+				Severity: diag.Error,
+				Summary:  "Incomplete list of cron jobs",
+				Detail:   detail + " Set fail_on_incomplete = false to use the partial list.",
+			}}
+		}
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "Incomplete list of cron jobs",
+			Detail:   detail + " Set fail_on_incomplete = true to fail instead.",
+		})
 	}
 
 	// Set a composite ID based on the number of jobs
 	d.SetId(fmt.Sprintf("jobs-%d", len(jobs)))
 
-	// For now, we'll set some_failed to false since we don't have that info from the current GetJobs response
-	// In a future enhancement, this could be updated to parse the actual API response
-	if err := d.Set("some_failed", false); err != nil {
+	if err := d.Set("some_failed", incomplete); err != nil {
 		return diag.FromErr(err)
 	}
 
@@ -193,14 +220,14 @@ func dataSourceJobsRead(ctx context.Context, d *schema.ResourceData, m interface
 			"title":            job.Title,
 			"save_responses":   job.SaveResponses,
 			"url":              job.URL,
-			"last_status":      job.LastStatus,
+			"last_status":      int(job.LastStatus),
 			"last_duration":    job.LastDuration,
 			"last_execution":   job.LastExecution,
-			"type":             job.Type,
+			"type":             int(job.Type),
 			"request_timeout":  job.RequestTimeout,
 			"redirect_success": job.RedirectSuccess,
 			"folder_id":        job.FolderID,
-			"request_method":   job.RequestMethod,
+			"request_method":   int(job.RequestMethod),
 			"schedule": []interface{}{
 				map[string]interface{}{
 					"timezone":   job.Schedule.Timezone,
@@ -226,5 +253,5 @@ func dataSourceJobsRead(ctx context.Context, d *schema.ResourceData, m interface
 		return diag.FromErr(err)
 	}
 
-	return nil
+	return diags
 }
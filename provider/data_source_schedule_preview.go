@@ -0,0 +1,145 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// schedulePreviewListFields are the time lists that cron_expression replaces.
+var schedulePreviewListFields = []string{"hours", "mdays", "minutes", "months", "wdays"}
+
+func dataSourceSchedulePreview() *schema.Resource {
+	scheduleList := func(description string, maxValue int) *schema.Schema {
+		return &schema.Schema{
+			Type:        schema.TypeList,
+			Optional:    true,
+			Description: description,
+			Elem: &schema.Schema{
+				Type:         schema.TypeInt,
+				ValidateFunc: validation.IntBetween(-1, maxValue),
+			},
+		}
+	}
+
+	return &schema.Resource{
+		Description: "Compute the next run times of a job schedule locally, without calling the API. " +
+			"The arguments match the `schedule` block of `cronjoborg_job`, so a schedule can be checked in the plan before it is applied.",
+		ReadContext: dataSourceSchedulePreviewRead,
+		Schema: map[string]*schema.Schema{
+			"timezone": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "UTC",
+				Description:  "Schedule time zone as an IANA time zone name, e.g. `Europe/Berlin`",
+				ValidateFunc: validateTimezone,
+			},
+			"expires_at": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				Description:  "Date/time in the schedule's time zone after which the job expires (format: YYYYMMDDhhmmss, 0 = does not expire)",
+				ValidateFunc: validateExpiresAt,
+			},
+			"expires_at_rfc3339": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Description:   "Date/time after which the job expires as an RFC3339 timestamp, to use instead of `expires_at`",
+				ConflictsWith: []string{"expires_at"},
+				ValidateFunc:  validation.IsRFC3339Time,
+			},
+			"cron_expression": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: "Five-field cron expression to use instead of `hours`, `mdays`, `minutes`, `months` and `wdays`, e.g. `*/15 9-17 * * MON-FRI`. " +
+					"Expressions that restrict both the day of month and the day of week are rejected, as cron-job.org only runs jobs on days that match both",
+				ConflictsWith: schedulePreviewListFields,
+				ValidateFunc:  validateCronExpression,
+			},
+			"hours":   scheduleList("Hours in which to execute the job (0-23; [-1] = every hour)", 23),
+			"mdays":   scheduleList("Days of month in which to execute the job (1-31; [-1] = every day of month)", 31),
+			"minutes": scheduleList("Minutes in which to execute the job (0-59; [-1] = every minute)", 59),
+			"months":  scheduleList("Months in which to execute the job (1-12; [-1] = every month)", 12),
+			"wdays":   scheduleList("Days of week in which to execute the job (0=Sunday-6=Saturday; [-1] = every day of week)", 6),
+			"after": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "List runs after this time (RFC3339, e.g. `2025-01-01T00:00:00Z`). Defaults to the current time",
+				ValidateFunc: validation.IsRFC3339Time,
+			},
+			"run_count": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      5,
+				Description:  "Number of run times to return (1-100)",
+				ValidateFunc: validation.IntBetween(1, 100),
+			},
+			"next_runs": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The next run times in RFC3339 format, in the schedule's time zone. Fewer than `run_count` times are returned if the schedule expires or does not match within the next 30 years",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+func dataSourceSchedulePreviewRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	scheduleMap := map[string]interface{}{}
+	for _, key := range append([]string{"timezone", "expires_at", "expires_at_rfc3339", "cron_expression"}, schedulePreviewListFields...) {
+		scheduleMap[key] = d.Get(key)
+	}
+	schedule, err := expandSchedule(scheduleMap)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	after := time.Now()
+	if value, _ := d.Get("after").(string); value != "" {
+		if after, err = time.Parse(time.RFC3339, value); err != nil {
+			return diag.Errorf("after must be an RFC3339 time: %s", err)
+		}
+	}
+
+	runCount, ok := d.Get("run_count").(int)
+	if !ok {
+		return diag.Errorf("run_count must be an integer")
+	}
+
+	runs, err := schedule.Next(after, runCount)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var diags diag.Diagnostics
+	if len(runs) == 0 {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "Schedule never runs",
+			Detail: fmt.Sprintf("The schedule %q in time zone %s has no run times after %s. "+
+				"Check expires_at and that the days of month exist in the selected months.",
+				schedule.CronExpression(), schedule.Timezone, after.Format(time.RFC3339)),
+		})
+	}
+
+	nextRuns := make([]string, len(runs))
+	for i, run := range runs {
+		nextRuns[i] = run.Format(time.RFC3339)
+	}
+
+	d.SetId(fmt.Sprintf("%s %s %d", schedule.CronExpression(), schedule.Timezone, schedule.ExpiresAt))
+	if err := d.Set("next_runs", nextRuns); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
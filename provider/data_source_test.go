@@ -4,9 +4,15 @@
 package provider
 
 import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/plain-insure/terraform-provider-cronjoborg/client"
 )
 
 func TestDataSourceJob_Schema(t *testing.T) {
@@ -104,3 +110,187 @@ func TestDataSourceJobHistory_Schema(t *testing.T) {
 		t.Error("history should be computed")
 	}
 }
+
+func TestDataSourceJobHistoryItem_Schema(t *testing.T) {
+	ds := dataSourceJobHistoryItem()
+
+	if ds == nil {
+		t.Fatal("dataSourceJobHistoryItem() returned nil")
+	}
+
+	for _, field := range []string{"job_id", "identifier"} {
+		s, ok := ds.Schema[field]
+		if !ok {
+			t.Fatalf("%s should be in schema", field)
+		}
+		if !s.Required {
+			t.Errorf("%s should be required", field)
+		}
+	}
+
+	for _, field := range []string{"status", "failed", "http_status", "headers", "body", "stats"} {
+		s, ok := ds.Schema[field]
+		if !ok {
+			t.Fatalf("%s should be in schema", field)
+		}
+		if !s.Computed {
+			t.Errorf("%s should be computed", field)
+		}
+	}
+}
+
+func TestDataSourceJobHistoryItem_Read(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/jobs/12345/history/12345-22-11-4946" {
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"jobHistoryDetails": {
+			"jobLogId": 4946, "jobId": 12345, "identifier": "12345-22-11-4946",
+			"status": 4, "statusText": "HTTP error", "httpStatus": 503,
+			"headers": "Retry-After: 60", "body": null,
+			"stats": {"total": 238129}
+		}}`))
+	}))
+	defer server.Close()
+
+	c := client.NewClient(server.URL, "test-key")
+	d := schema.TestResourceDataRaw(t, dataSourceJobHistoryItem().Schema, map[string]interface{}{
+		"job_id":     12345,
+		"identifier": "12345-22-11-4946",
+	})
+
+	if diags := dataSourceJobHistoryItemRead(context.Background(), d, c); diags.HasError() {
+		t.Fatalf("Expected no error, got %v", diags)
+	}
+
+	if d.Id() != "job-12345-history-12345-22-11-4946" {
+		t.Errorf("Unexpected ID %q", d.Id())
+	}
+	if d.Get("status") != int(client.JobStatusFailedHTTP) || d.Get("failed") != true {
+		t.Errorf("Expected failed execution, got status %v", d.Get("status"))
+	}
+	if d.Get("headers") != "Retry-After: 60" || d.Get("body") != "" {
+		t.Errorf("Unexpected headers %q / body %q", d.Get("headers"), d.Get("body"))
+	}
+	if d.Get("stats.0.total") != 238129 {
+		t.Errorf("Expected total transfer time 238129, got %v", d.Get("stats.0.total"))
+	}
+}
+
+func TestDataSourceJobs_ReadIncomplete(t *testing.T) {
+	var calls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"jobs": [{"jobId": 1, "title": "Listed"}], "someFailed": true}`))
+	}))
+	defer server.Close()
+
+	testCases := []struct {
+		name             string
+		failOnIncomplete bool
+		expectError      bool
+	}{
+		{name: "warning", failOnIncomplete: false, expectError: false},
+		{name: "fail_on_incomplete", failOnIncomplete: true, expectError: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			calls = 0
+			c := client.NewClient(server.URL, "test-key")
+			c.RateLimiter = nil
+			c.Retry.MinWait = time.Millisecond
+			c.IncompleteListRetries = 1
+			d := schema.TestResourceDataRaw(t, dataSourceJobs().Schema, map[string]interface{}{
+				"fail_on_incomplete": tc.failOnIncomplete,
+			})
+
+			diags := dataSourceJobsRead(context.Background(), d, c)
+			if calls != 2 {
+				t.Errorf("Expected the incomplete listing to be retried once, got %d requests", calls)
+			}
+			if len(diags) != 1 || diags[0].Summary != "Incomplete list of cron jobs" {
+				t.Fatalf("Expected one incomplete list diagnostic, got %v", diags)
+			}
+			if diags.HasError() != tc.expectError {
+				t.Fatalf("Expected error %v, got diagnostics %v", tc.expectError, diags)
+			}
+			if tc.expectError {
+				return
+			}
+			if d.Get("some_failed") != true || d.Get("jobs.#") != 1 {
+				t.Errorf("Expected the partial list with some_failed set, got some_failed %v and %v jobs", d.Get("some_failed"), d.Get("jobs.#"))
+			}
+		})
+	}
+}
+
+func TestDataSourceSchedulePreview_Read(t *testing.T) {
+	testCases := []struct {
+		name        string
+		config      map[string]interface{}
+		wantRuns    []interface{}
+		wantWarning bool
+	}{
+		{
+			name: "cron_expression",
+			config: map[string]interface{}{
+				"timezone":        "Europe/Berlin",
+				"cron_expression": "*/15 9-17 * * MON-FRI",
+				"after":           "2025-01-03T16:50:00Z",
+				"run_count":       2,
+			},
+			wantRuns: []interface{}{"2025-01-06T09:00:00+01:00", "2025-01-06T09:15:00+01:00"},
+		},
+		{
+			name: "lists",
+			config: map[string]interface{}{
+				"hours":     []interface{}{3},
+				"minutes":   []interface{}{30},
+				"mdays":     []interface{}{1, 15},
+				"after":     "2025-01-01T00:00:00Z",
+				"run_count": 3,
+			},
+			wantRuns: []interface{}{"2025-01-01T03:30:00Z", "2025-01-15T03:30:00Z", "2025-02-01T03:30:00Z"},
+		},
+		{
+			name: "expired",
+			config: map[string]interface{}{
+				"cron_expression": "@daily",
+				"expires_at":      20241231000000,
+				"after":           "2025-01-01T00:00:00Z",
+			},
+			wantRuns:    []interface{}{},
+			wantWarning: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, dataSourceSchedulePreview().Schema, tc.config)
+
+			diags := dataSourceSchedulePreviewRead(context.Background(), d, nil)
+			if diags.HasError() {
+				t.Fatalf("Expected no error, got %v", diags)
+			}
+			if gotWarning := len(diags) == 1 && diags[0].Summary == "Schedule never runs"; gotWarning != tc.wantWarning {
+				t.Errorf("Expected warning %v, got diagnostics %v", tc.wantWarning, diags)
+			}
+			if got := d.Get("next_runs"); !reflect.DeepEqual(got, tc.wantRuns) {
+				t.Errorf("Expected next_runs %v, got %v", tc.wantRuns, got)
+			}
+		})
+	}
+}
+
+func TestDataSourceSchedulePreview_InvalidTimezone(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, dataSourceSchedulePreview().Schema, map[string]interface{}{
+		"timezone": "Mars/Olympus_Mons",
+	})
+
+	if diags := dataSourceSchedulePreviewRead(context.Background(), d, nil); !diags.HasError() {
+		t.Fatal("Expected an error for an unknown time zone")
+	}
+}
@@ -0,0 +1,27 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/plain-insure/terraform-provider-cronjoborg/client"
+)
+
+// enumDescription appends the numeric value mapping of an API enum to a schema description,
+// e.g. "Job type (0=Default job, 1=Monitoring job)".
+func enumDescription[T ~int](prefix string, values []T, label func(T) string) string {
+	parts := make([]string, len(values))
+	for i, v := range values {
+		parts[i] = fmt.Sprintf("%d=%s", int(v), label(v))
+	}
+	return fmt.Sprintf("%s (%s)", prefix, strings.Join(parts, ", "))
+}
+
+var (
+	jobStatusDescription     = enumDescription("Last execution status", client.JobStatuses(), client.JobStatus.Description)
+	jobTypeDescription       = enumDescription("Job type", client.JobTypes(), client.JobType.Description)
+	requestMethodDescription = enumDescription("HTTP request method", client.RequestMethods(), client.RequestMethod.String)
+)
@@ -0,0 +1,82 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"errors"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/plain-insure/terraform-provider-cronjoborg/client"
+)
+
+// apiErrorHints maps API error classes to remediation hints shown in the diagnostic detail.
+var apiErrorHints = []struct {
+	err  error
+	hint string
+}{
+	{
+		client.ErrUnauthorized,
+		"The API key was rejected. Check the api_key provider setting or the CRON_JOB_API_KEY environment variable, " +
+			"and make sure the key still exists in the cron-job.org console under Settings > API.",
+	},
+	{
+		client.ErrForbiddenIP,
+		"The API key is restricted to specific IP addresses and this request came from an address that is not allowlisted. " +
+			"Add the public IP address of the machine running Terraform (for CI, the runner's egress address) to the key's " +
+			"IP allowlist in the cron-job.org console under Settings > API, or remove the restriction.",
+	},
+	{
+		client.ErrNotFound,
+		"The job does not exist or belongs to another account. If it was deleted outside of Terraform, " +
+			"remove it from the state with `terraform state rm`.",
+	},
+	{
+		client.ErrBadRequest,
+		"The API rejected the request data. Check the job settings in the configuration against the limits " +
+			"documented for the cron-job.org API.",
+	},
+	{
+		client.ErrConflict,
+		"The request conflicts with an existing resource.",
+	},
+	{
+		client.ErrRateLimited,
+		"The API rate limit was still exceeded after retrying. Reduce Terraform's -parallelism, lower rate_limit_per_second " +
+			"or the create_rate_limit_* settings, or raise retry_max_attempts and retry_max_wait_seconds.",
+	},
+	{
+		client.ErrQuotaExceeded,
+		"The API key's daily request quota or the account's job quota has been reached. Wait until the quota resets, " +
+			"delete unused jobs, or upgrade the account. Set daily_request_budget to stop before the quota is reached.",
+	},
+	{
+		client.ErrBudgetExhausted,
+		"The provider's own request budget stopped the request before it was sent. Raise daily_request_budget or " +
+			"max_requests_per_run, or wait until the daily budget resets at midnight UTC. With request_budget_state_file, " +
+			"the count includes all runs of the day.",
+	},
+	{
+		client.ErrServerError,
+		"The cron-job.org API reported an internal error. This is usually temporary; run Terraform again later.",
+	},
+}
+
+// apiErrorDiag converts an error returned by the client into an error diagnostic.
+// The summary describes the failed operation; the detail holds the error and, for
+// known API error classes, a hint on how to resolve it.
+func apiErrorDiag(summary string, err error) diag.Diagnostics {
+	detail := err.Error()
+	for _, h := range apiErrorHints {
+		if errors.Is(err, h.err) {
+			detail += "\n\n" + h.hint
+			break
+		}
+	}
+
+	return diag.Diagnostics{{
+		Severity: diag.Error,
+		Summary:  summary,
+		Detail:   detail,
+	}}
+}
@@ -0,0 +1,90 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/plain-insure/terraform-provider-cronjoborg/client"
+	"github.com/plain-insure/terraform-provider-cronjoborg/client/clienttest"
+)
+
+func TestAPIErrorDiag(t *testing.T) {
+	testCases := []struct {
+		name     string
+		err      error
+		contains string
+	}{
+		{"unauthorized", &client.APIError{StatusCode: 401, Message: "Unauthorized"}, "CRON_JOB_API_KEY"},
+		{"forbidden", &client.APIError{StatusCode: 403, Message: "Forbidden"}, "IP allowlist"},
+		{"wrapped forbidden", fmt.Errorf("retry aborted: %w", &client.APIError{StatusCode: 403}), "IP allowlist"},
+		{"not found", &client.APIError{StatusCode: 404}, "terraform state rm"},
+		{"rate limited", &client.APIError{StatusCode: 429, Message: "Too many requests"}, "-parallelism"},
+		{"quota", &client.APIError{StatusCode: 429, Message: "Quota exceeded"}, "daily_request_budget"},
+		{"server error", &client.APIError{StatusCode: 500}, "usually temporary"},
+		{"budget exhausted", fmt.Errorf("%w: all 100 requests allowed per run have been used", client.ErrBudgetExhausted), "max_requests_per_run"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			diags := apiErrorDiag("Error reading cron job 1", tc.err)
+			if len(diags) != 1 || diags[0].Severity != diag.Error {
+				t.Fatalf("Expected a single error diagnostic, got %v", diags)
+			}
+			if diags[0].Summary != "Error reading cron job 1" {
+				t.Errorf("Unexpected summary %q", diags[0].Summary)
+			}
+			if !strings.HasPrefix(diags[0].Detail, tc.err.Error()) {
+				t.Errorf("Expected detail to start with the error, got %q", diags[0].Detail)
+			}
+			if !strings.Contains(diags[0].Detail, tc.contains) {
+				t.Errorf("Expected detail to contain %q, got %q", tc.contains, diags[0].Detail)
+			}
+		})
+	}
+
+	// Errors without a known class are reported as-is.
+	diags := apiErrorDiag("Error creating cron job", errors.New("connection refused"))
+	if diags[0].Detail != "connection refused" {
+		t.Errorf("Expected unclassified error without hint, got %q", diags[0].Detail)
+	}
+}
+
+func TestResourceJobRead_NotFound(t *testing.T) {
+	c := &clienttest.MockJobsAPI{
+		GetJobDetailsFunc: func(ctx context.Context, jobID string) (*client.DetailedJob, error) {
+			return nil, &client.APIError{Method: http.MethodGet, Path: "/jobs/" + jobID, StatusCode: http.StatusNotFound, Message: "Job not found"}
+		},
+	}
+
+	// A previously read job that disappeared is removed from state.
+	d := resourceJob().TestResourceData()
+	d.SetId("123")
+	if err := d.Set("job_id", 123); err != nil {
+		t.Fatal(err)
+	}
+	if diags := resourceJobRead(context.Background(), d, c); diags.HasError() {
+		t.Fatalf("Expected no error, got %v", diags)
+	}
+	if d.Id() != "" {
+		t.Errorf("Expected job to be removed from state, got ID %q", d.Id())
+	}
+
+	// A job that has never been read (e.g. on import) must exist.
+	d = resourceJob().TestResourceData()
+	d.SetId("456")
+	diags := resourceJobRead(context.Background(), d, c)
+	if !diags.HasError() {
+		t.Fatal("Expected an error for a missing imported job")
+	}
+	if diags[0].Summary != "Cron job 456 not found" {
+		t.Errorf("Unexpected summary %q", diags[0].Summary)
+	}
+}
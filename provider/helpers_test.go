@@ -0,0 +1,112 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/plain-insure/terraform-provider-cronjoborg/client"
+	"github.com/plain-insure/terraform-provider-cronjoborg/internal/fakeapi"
+	"github.com/plain-insure/terraform-provider-cronjoborg/internal/recorder"
+	"github.com/plain-insure/terraform-provider-cronjoborg/internal/redact"
+)
+
+// testFakeAPIPreCheck skips tests that run the Terraform CLI against the fake API
+// when no Terraform binary is available. Unlike acceptance tests they need neither
+// TF_ACC nor an API key.
+func testFakeAPIPreCheck(t *testing.T) {
+	if os.Getenv("TF_ACC_TERRAFORM_PATH") != "" || os.Getenv("TF_ACC_TERRAFORM_VERSION") != "" {
+		return
+	}
+	if _, err := exec.LookPath("terraform"); err != nil {
+		t.Skip("terraform CLI not found in PATH; install Terraform or set TF_ACC_TERRAFORM_PATH to run this test")
+	}
+}
+
+// testFakeAPIProviderConfig returns a provider block pointing at the fake API server.
+// settings are added to the block as additional lines, e.g. "bulk_refresh = true".
+func testFakeAPIProviderConfig(s *fakeapi.Server, settings ...string) string {
+	var extra strings.Builder
+	for _, setting := range settings {
+		extra.WriteString("  " + setting + "\n")
+	}
+	return fmt.Sprintf(`
+provider "cronjoborg" {
+  api_url = %q
+  api_key = %q
+%s}
+`, s.URL, s.API.APIKey(), extra.String())
+}
+
+// testAccRun runs an acceptance test. Depending on CRONJOBORG_RECORDER_MODE, the API
+// interactions are recorded to or replayed from testdata/cassettes/<test name>.json.
+// Replay needs neither TF_ACC, an API key nor network access.
+func testAccRun(t *testing.T, tc resource.TestCase) {
+	t.Helper()
+
+	mode, err := recorder.ModeFromEnv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mode == recorder.ModeOff {
+		resource.Test(t, tc)
+		return
+	}
+
+	path := filepath.Join("testdata", "cassettes", t.Name()+".json")
+	apiKey := os.Getenv("CRON_JOB_API_KEY")
+	if mode == recorder.ModeReplay {
+		// Replayed requests never reach the API, so any key works.
+		apiKey = redact.Redacted
+		t.Setenv("CRON_JOB_API_KEY", apiKey)
+		tc.PreCheck = func() { testFakeAPIPreCheck(t) }
+	}
+
+	rec, err := recorder.New(mode, path, nil, apiKey)
+	if err != nil {
+		t.Fatalf("%s (record it with %s=record and a real API key)", err, recorder.EnvMode)
+	}
+	rec.RedactHeaders(idempotencyKeyHeader)
+	t.Cleanup(func() {
+		if err := rec.Stop(); err != nil {
+			t.Errorf("recorder: %s", err)
+		}
+	})
+
+	tc.ProviderFactories = testRecorderProviderFactories(rec)
+	if mode == recorder.ModeReplay {
+		resource.UnitTest(t, tc)
+	} else {
+		resource.Test(t, tc)
+	}
+}
+
+// testRecorderProviderFactories returns provider factories whose client sends all
+// requests through rec.
+func testRecorderProviderFactories(rec *recorder.Recorder) map[string]func() (*schema.Provider, error) {
+	return map[string]func() (*schema.Provider, error){
+		"cronjoborg": func() (*schema.Provider, error) { //nolint:unparam // Required by Terraform test framework
+			p := Provider()
+			configure := p.ConfigureContextFunc
+			p.ConfigureContextFunc = func(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
+				meta, diags := configure(ctx, d)
+				if c, ok := meta.(*client.Client); ok {
+					c.HTTPClient = &http.Client{Transport: rec}
+				}
+				return meta, diags
+			}
+			return p, nil
+		},
+	}
+}
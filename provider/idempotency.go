@@ -0,0 +1,200 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+	"github.com/plain-insure/terraform-provider-cronjoborg/client"
+)
+
+// idempotencyKeyHeader marks a job with a random key generated for the request that
+// created it if recover_failed_creates is enabled. The cron-job.org API has no
+// idempotency support, so after a create request fails without a definite answer, the
+// key is the only way to tell the job it may have created apart from other jobs with
+// the same title and URL. The header is removed again once the job has been created,
+// and hidden from the Terraform state in case removing it failed.
+const idempotencyKeyHeader = "X-Cronjoborg-Idempotency-Key"
+
+var (
+	// orphanLookupDelays are the delays before each search for a job whose create
+	// request failed ambiguously. The job list may lag behind a successful create.
+	orphanLookupDelays = []time.Duration{0, 2 * time.Second, 5 * time.Second}
+
+	// createReadTimeout bounds the retries of reading a job that was just created
+	// but is not yet visible to the job details endpoint.
+	createReadTimeout = 30 * time.Second
+)
+
+// newIdempotencyKey returns a random key for idempotencyKeyHeader.
+func newIdempotencyKey() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate idempotency key: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// setIdempotencyKey adds idempotencyKeyHeader with key to the job's headers.
+func setIdempotencyKey(job *client.JobCreate, key string) {
+	if job.ExtendedData == nil {
+		job.ExtendedData = &client.JobExtendedData{}
+	}
+	headers := make(map[string]string, len(job.ExtendedData.Headers)+1)
+	for name, value := range job.ExtendedData.Headers {
+		headers[name] = value
+	}
+	headers[idempotencyKeyHeader] = key
+	job.ExtendedData.Headers = headers
+}
+
+// removeIdempotencyKey removes idempotencyKeyHeader from the headers of a created job,
+// so the header is not sent to the job's URL on every execution.
+func removeIdempotencyKey(ctx context.Context, c client.JobsAPI, jobID string, job *client.DetailedJob) error {
+	if idempotencyKey(job.ExtendedData.Headers) == "" {
+		return nil
+	}
+	extendedData := client.JobExtendedData{
+		Headers: withoutIdempotencyKey(job.ExtendedData.Headers),
+		Body:    job.ExtendedData.Body,
+	}
+	if err := c.UpdateJob(ctx, jobID, client.JobPatch{ExtendedData: &extendedData}); err != nil {
+		return err
+	}
+	job.ExtendedData = extendedData
+	return nil
+}
+
+// idempotencyKey returns the value of idempotencyKeyHeader in headers. Header names
+// are matched case-insensitively in case the API normalizes them.
+func idempotencyKey(headers map[string]string) string {
+	for name, value := range headers {
+		if strings.EqualFold(name, idempotencyKeyHeader) {
+			return value
+		}
+	}
+	return ""
+}
+
+// withoutIdempotencyKey returns headers without idempotencyKeyHeader.
+func withoutIdempotencyKey(headers map[string]string) map[string]string {
+	if idempotencyKey(headers) == "" {
+		return headers
+	}
+	stripped := make(map[string]string, len(headers)-1)
+	for name, value := range headers {
+		if !strings.EqualFold(name, idempotencyKeyHeader) {
+			stripped[name] = value
+		}
+	}
+	return stripped
+}
+
+// isAmbiguousCreateError reports whether a failed create request may have created the
+// job anyway: the request reached the API, but no complete response made it back, or
+// the API failed with a server error after committing the job. Requests that were not
+// sent, such as invalid jobs, aborted rate limiter waits, an exhausted request budget
+// or unreachable hosts, and client errors mean that no job was created.
+func isAmbiguousCreateError(err error) bool {
+	if errors.Is(err, client.ErrNotSent) {
+		return false
+	}
+	var apiErr *client.APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode >= 500
+	}
+	return true
+}
+
+// maybeCreatedDiag reports a failed create request that may have created the job,
+// which was not looked up for the given reason.
+func maybeCreatedDiag(createErr error, reason string, job client.JobCreate) diag.Diagnostics {
+	diags := apiErrorDiag("Error creating cron job", createErr)
+	diags[0].Detail += fmt.Sprintf("\n\nThe job may have been created anyway, but %s. "+
+		"If the cron-job.org console shows a job titled %q, import it with `terraform import` instead of applying again.", reason, job.Title)
+	return diags
+}
+
+// findCreatedJob searches the job list for a job with the title and URL of job that
+// carries the idempotency key, waiting orphanLookupDelays between attempts. It
+// returns the ID of the job, or false if no job was created with the key.
+func findCreatedJob(ctx context.Context, c client.JobsAPI, job client.JobCreate, key string) (string, bool, error) {
+	for _, delay := range orphanLookupDelays {
+		if delay > 0 {
+			timer := time.NewTimer(delay)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return "", false, ctx.Err()
+			case <-timer.C:
+			}
+		}
+
+		jobID, found, err := lookupCreatedJob(ctx, c, job, key)
+		if err != nil || found {
+			return jobID, found, err
+		}
+	}
+	return "", false, nil
+}
+
+// lookupCreatedJob performs a single search for findCreatedJob. Only the details of
+// jobs matching the title and URL are read, newest first.
+func lookupCreatedJob(ctx context.Context, c client.JobsAPI, job client.JobCreate, key string) (string, bool, error) {
+	jobs, _, err := c.GetJobs(ctx)
+	if err != nil {
+		return "", false, err
+	}
+
+	var candidates []int
+	for _, listed := range jobs {
+		if listed.Title == job.Title && listed.URL == job.URL {
+			candidates = append(candidates, listed.JobID)
+		}
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(candidates)))
+
+	for _, id := range candidates {
+		jobID := strconv.Itoa(id)
+		details, err := c.GetJobDetails(ctx, jobID)
+		if errors.Is(err, client.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return "", false, err
+		}
+		if idempotencyKey(details.ExtendedData.Headers) == key {
+			return jobID, true, nil
+		}
+	}
+	return "", false, nil
+}
+
+// readCreatedJob reads the details of a job that was just created, retrying for up to
+// createReadTimeout while the API does not find it yet.
+func readCreatedJob(ctx context.Context, c client.JobsAPI, jobID string) (*client.DetailedJob, error) {
+	var jobDetails *client.DetailedJob
+	err := retry.RetryContext(ctx, createReadTimeout, func() *retry.RetryError {
+		var err error
+		jobDetails, err = c.GetJobDetails(ctx, jobID)
+		if errors.Is(err, client.ErrNotFound) {
+			return retry.RetryableError(err)
+		}
+		if err != nil {
+			return retry.NonRetryableError(err)
+		}
+		return nil
+	})
+	return jobDetails, err
+}
@@ -0,0 +1,331 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/plain-insure/terraform-provider-cronjoborg/client"
+	"github.com/plain-insure/terraform-provider-cronjoborg/client/clienttest"
+)
+
+// testMockRecoverJobConfig is testMockJobConfig with recover_failed_creates enabled.
+var testMockRecoverJobConfig = map[string]interface{}{
+	"title":                  "Backup",
+	"url":                    "https://example.com/backup",
+	"recover_failed_creates": true,
+}
+
+// withFastOrphanLookup removes the waits of findCreatedJob and readCreatedJob for the test.
+func withFastOrphanLookup(t *testing.T) {
+	t.Helper()
+
+	delays, timeout := orphanLookupDelays, createReadTimeout
+	orphanLookupDelays = []time.Duration{0, 0}
+	createReadTimeout = 2 * time.Second
+	t.Cleanup(func() {
+		orphanLookupDelays, createReadTimeout = delays, timeout
+	})
+}
+
+func TestIsAmbiguousCreateError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"transport error", errors.New("connection reset by peer"), true},
+		{"server error", &client.APIError{Method: http.MethodPut, Path: "/jobs", StatusCode: http.StatusBadGateway}, true},
+		{"client error", &client.APIError{Method: http.MethodPut, Path: "/jobs", StatusCode: http.StatusBadRequest}, false},
+		{"not sent", fmt.Errorf("creating job: %w", client.ErrNotSent), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isAmbiguousCreateError(tt.err); got != tt.want {
+				t.Errorf("isAmbiguousCreateError() = %t, want %t", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWithoutIdempotencyKey(t *testing.T) {
+	headers := map[string]string{
+		"x-cronjoborg-idempotency-key": "abc",
+		"X-Custom":                     "value",
+	}
+	want := map[string]string{"X-Custom": "value"}
+	if got := withoutIdempotencyKey(headers); !reflect.DeepEqual(got, want) {
+		t.Errorf("withoutIdempotencyKey() = %v, want %v", got, want)
+	}
+	if len(headers) != 2 {
+		t.Errorf("withoutIdempotencyKey() modified its argument: %v", headers)
+	}
+}
+
+// testMockCreateAPI returns a mock API whose create requests fail with createErr after
+// storing the job as job 2 if stored is true. Job 1 is an unrelated job with the same
+// title and URL.
+func testMockCreateAPI(createErr error, stored bool) *clienttest.MockJobsAPI {
+	var created *client.DetailedJob
+	return &clienttest.MockJobsAPI{
+		CreateJobFunc: func(ctx context.Context, job client.JobCreate) (int, error) {
+			if stored {
+				created = testMockJob()
+				created.JobID = 2
+				created.ExtendedData.Headers = job.ExtendedData.Headers
+			}
+			return 0, createErr
+		},
+		GetJobsFunc: func(ctx context.Context) ([]client.Job, bool, error) {
+			jobs := []client.Job{testMockJob().Job}
+			if created != nil {
+				jobs = append(jobs, created.Job)
+			}
+			return jobs, false, nil
+		},
+		GetJobDetailsFunc: func(ctx context.Context, jobID string) (*client.DetailedJob, error) {
+			if created != nil && jobID == "2" {
+				return created, nil
+			}
+			if jobID == "1" {
+				job := testMockJob()
+				job.ExtendedData.Headers = map[string]string{idempotencyKeyHeader: "other"}
+				return job, nil
+			}
+			return nil, client.ErrNotFound
+		},
+	}
+}
+
+func TestResourceJobCreate_AdoptsOrphan(t *testing.T) {
+	withFastOrphanLookup(t)
+
+	api := testMockCreateAPI(errors.New("connection reset by peer"), true)
+	var patched map[string]string
+	api.UpdateJobFunc = func(ctx context.Context, jobID string, patch client.JobPatch) error {
+		if jobID != "2" || patch.ExtendedData == nil {
+			return fmt.Errorf("unexpected patch of job %s: %+v", jobID, patch)
+		}
+		patched = patch.ExtendedData.Headers
+		return nil
+	}
+	d := schema.TestResourceDataRaw(t, resourceJob().Schema, testMockRecoverJobConfig)
+
+	diags := resourceJobCreate(context.Background(), d, api)
+	if diags.HasError() {
+		t.Fatalf("Create failed: %v", diags)
+	}
+	if len(diags) != 1 || diags[0].Severity != diag.Warning {
+		t.Errorf("expected a single warning, got %v", diags)
+	}
+	if d.Id() != "2" {
+		t.Errorf("expected ID 2, got %q", d.Id())
+	}
+	for name, value := range d.State().Attributes {
+		if strings.Contains(strings.ToLower(name), "idempotency") {
+			t.Errorf("expected the idempotency key to be hidden from state, got %s = %s", name, value)
+		}
+	}
+	if patched == nil || idempotencyKey(patched) != "" {
+		t.Errorf("expected the idempotency key to be removed from the adopted job, got headers %v", patched)
+	}
+}
+
+func TestResourceJobCreate_NoOrphan(t *testing.T) {
+	withFastOrphanLookup(t)
+
+	api := testMockCreateAPI(errors.New("connection reset by peer"), false)
+	d := schema.TestResourceDataRaw(t, resourceJob().Schema, testMockRecoverJobConfig)
+
+	diags := resourceJobCreate(context.Background(), d, api)
+	if !diags.HasError() {
+		t.Fatal("expected an error")
+	}
+	if d.Id() != "" {
+		t.Errorf("expected no ID, got %q", d.Id())
+	}
+
+	want := []string{"CreateJob", "GetJobs", "GetJobDetails 1", "GetJobs", "GetJobDetails 1"}
+	if got := api.Calls(); !reflect.DeepEqual(got, want) {
+		t.Errorf("calls = %v, want %v", got, want)
+	}
+}
+
+func TestResourceJobCreate_ClientErrorSkipsLookup(t *testing.T) {
+	withFastOrphanLookup(t)
+
+	createErr := &client.APIError{Method: http.MethodPut, Path: "/jobs", StatusCode: http.StatusBadRequest}
+	api := testMockCreateAPI(createErr, false)
+	d := schema.TestResourceDataRaw(t, resourceJob().Schema, testMockJobConfig)
+
+	if diags := resourceJobCreate(context.Background(), d, api); !diags.HasError() {
+		t.Fatal("expected an error")
+	}
+	if got, want := api.Calls(), []string{"CreateJob"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("calls = %v, want %v", got, want)
+	}
+}
+
+func TestResourceJobCreate_NotSentSkipsLookup(t *testing.T) {
+	withFastOrphanLookup(t)
+
+	unreachable := httptest.NewServer(http.NotFoundHandler())
+	unreachable.Close()
+	// Unsent creates are retried, which would only slow the test down.
+	newClient := func() *client.Client {
+		return client.NewClient(unreachable.URL, "test-key", client.WithRetry(client.RetryPolicy{MaxAttempts: 1}))
+	}
+
+	tests := []struct {
+		name string
+		// create returns the client and context for the create request.
+		create func(t *testing.T) (*client.Client, context.Context)
+		// invalid makes the job invalid before it is sent.
+		invalid bool
+	}{
+		{
+			name: "invalid job",
+			create: func(t *testing.T) (*client.Client, context.Context) {
+				return newClient(), context.Background()
+			},
+			invalid: true,
+		},
+		{
+			name: "cancelled context",
+			create: func(t *testing.T) (*client.Client, context.Context) {
+				ctx, cancel := context.WithCancel(context.Background())
+				cancel()
+				return newClient(), ctx
+			},
+		},
+		{
+			name: "rate limiter wait aborted",
+			create: func(t *testing.T) (*client.Client, context.Context) {
+				c := newClient()
+				c.RateLimiter = client.NewRateLimiter(client.DefaultRateLimits())
+				_, _ = c.CreateJob(context.Background(), client.JobCreate{})
+
+				ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+				t.Cleanup(cancel)
+				return c, ctx
+			},
+		},
+		{
+			name: "budget exhausted",
+			create: func(t *testing.T) (*client.Client, context.Context) {
+				c := newClient()
+				c.Quota = client.NewQuotaTracker("test-key", client.QuotaBudget{MaxPerRun: 1})
+				_, _ = c.CreateJob(context.Background(), client.JobCreate{})
+				return c, context.Background()
+			},
+		},
+		{
+			name: "host unreachable",
+			create: func(t *testing.T) (*client.Client, context.Context) {
+				return newClient(), context.Background()
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, ctx := tt.create(t)
+			api := &clienttest.MockJobsAPI{
+				CreateJobFunc: func(ctx context.Context, job client.JobCreate) (int, error) {
+					if tt.invalid {
+						job.RequestMethod = client.RequestMethod(99)
+					}
+					return c.CreateJob(ctx, job)
+				},
+			}
+			d := schema.TestResourceDataRaw(t, resourceJob().Schema, testMockRecoverJobConfig)
+
+			if diags := resourceJobCreate(ctx, d, api); !diags.HasError() {
+				t.Fatal("expected an error")
+			}
+			if got, want := api.Calls(), []string{"CreateJob"}; !reflect.DeepEqual(got, want) {
+				t.Errorf("calls = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestResourceJobCreate_CancelledAfterSendSkipsLookup(t *testing.T) {
+	withFastOrphanLookup(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	api := testMockCreateAPI(errors.New("connection reset by peer"), true)
+	api.CreateJobFunc = func(context.Context, client.JobCreate) (int, error) {
+		cancel()
+		return 0, errors.New("connection reset by peer")
+	}
+	d := schema.TestResourceDataRaw(t, resourceJob().Schema, testMockRecoverJobConfig)
+
+	diags := resourceJobCreate(ctx, d, api)
+	if !diags.HasError() || !strings.Contains(diags[0].Detail, "may have been created anyway") {
+		t.Fatalf("expected an error pointing out that the job may exist, got %v", diags)
+	}
+	if got, want := api.Calls(), []string{"CreateJob"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("calls = %v, want %v", got, want)
+	}
+}
+
+func TestResourceJobCreate_AmbiguousWithoutRecovery(t *testing.T) {
+	withFastOrphanLookup(t)
+
+	var sent client.JobCreate
+	api := testMockCreateAPI(errors.New("connection reset by peer"), true)
+	api.CreateJobFunc = func(ctx context.Context, job client.JobCreate) (int, error) {
+		sent = job
+		return 0, errors.New("connection reset by peer")
+	}
+	d := schema.TestResourceDataRaw(t, resourceJob().Schema, testMockJobConfig)
+
+	diags := resourceJobCreate(context.Background(), d, api)
+	if !diags.HasError() || !strings.Contains(diags[0].Detail, "recover_failed_creates") {
+		t.Fatalf("expected an error pointing out recover_failed_creates, got %v", diags)
+	}
+	if sent.ExtendedData != nil && idempotencyKey(sent.ExtendedData.Headers) != "" {
+		t.Errorf("expected no idempotency key without recover_failed_creates, got headers %v", sent.ExtendedData.Headers)
+	}
+	if got, want := api.Calls(), []string{"CreateJob"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("calls = %v, want %v", got, want)
+	}
+}
+
+func TestResourceJobCreate_RetriesReadAfterCreate(t *testing.T) {
+	withFastOrphanLookup(t)
+
+	reads := 0
+	api := &clienttest.MockJobsAPI{
+		CreateJobFunc: func(ctx context.Context, job client.JobCreate) (int, error) {
+			return 1, nil
+		},
+		GetJobDetailsFunc: func(ctx context.Context, jobID string) (*client.DetailedJob, error) {
+			reads++
+			if reads == 1 {
+				return nil, client.ErrNotFound
+			}
+			return testMockJob(), nil
+		},
+	}
+	d := schema.TestResourceDataRaw(t, resourceJob().Schema, testMockJobConfig)
+
+	if diags := resourceJobCreate(context.Background(), d, api); diags.HasError() {
+		t.Fatalf("Create failed: %v", diags)
+	}
+	if d.Id() != "1" || reads != 2 {
+		t.Errorf("expected job 1 after 2 reads, got %q after %d", d.Id(), reads)
+	}
+}
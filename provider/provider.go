@@ -5,44 +5,174 @@ package provider
 
 import (
 	"context"
+	"crypto/x509"
+	"fmt"
+	"net/url"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/plain-insure/terraform-provider-cronjoborg/client"
 )
 
-func Provider() *schema.Provider {
-	return &schema.Provider{
-		Schema: map[string]*schema.Schema{
-			"api_url": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Default:     "https://api.cron-job.org",
-				Description: "Base URL for the cron-job API.",
+// New returns a function that creates the provider. version is the provider version
+// reported in the User-Agent header of API requests.
+func New(version string) func() *schema.Provider {
+	return func() *schema.Provider {
+		p := &schema.Provider{
+			Schema: map[string]*schema.Schema{
+				"api_url": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Default:     "https://api.cron-job.org",
+					Description: "Base URL for the cron-job API.",
+				},
+				"api_key": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Sensitive:   true,
+					DefaultFunc: schema.EnvDefaultFunc("CRON_JOB_API_KEY", nil),
+					Description: "API key for the cron-job API. Can also be set via CRON_JOB_API_KEY env variable.",
+				},
+				"retry_max_attempts": {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Default:      client.DefaultRetryMaxAttempts,
+					Description:  "Maximum number of attempts per API request, including the first one, when the API responds with a rate limit (429) or transient server error (5xx). Set to 1 to disable retries.",
+					ValidateFunc: validation.IntAtLeast(1),
+				},
+				"retry_max_wait_seconds": {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Default:      int(client.DefaultRetryMaxWait / time.Second),
+					Description:  "Maximum number of seconds to wait between two attempts of an API request.",
+					ValidateFunc: validation.IntAtLeast(1),
+				},
+				"request_timeout_seconds": {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Default:      int(client.DefaultTimeout / time.Second),
+					Description:  "Maximum number of seconds a single attempt of an API request may take, including reading the response. Set to 0 to disable the timeout.",
+					ValidateFunc: validation.IntAtLeast(0),
+				},
+				"proxy_url": {
+					Type:         schema.TypeString,
+					Optional:     true,
+					Description:  "URL of the HTTP(S) or SOCKS5 proxy used for API requests, for example `http://proxy.example.com:3128`. Defaults to the proxy configured by the HTTPS_PROXY, HTTP_PROXY and NO_PROXY env variables.",
+					ValidateFunc: validation.IsURLWithScheme([]string{"http", "https", "socks5"}),
+				},
+				"ca_cert_file": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "Path of a PEM file with additional CA certificates trusted for the API's TLS certificate, for example the CA of a TLS-intercepting proxy. The certificates are added to the system's trusted CAs.",
+				},
+				"insecure_skip_verify": {
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Default:     false,
+					Description: "Skip verification of the API's TLS certificate. This makes API requests vulnerable to interception and should only be used for testing.",
+				},
+				"sensitive_headers": {
+					Type:        schema.TypeSet,
+					Optional:    true,
+					Elem:        &schema.Schema{Type: schema.TypeString},
+					Description: "Names of job request headers (`extended_data.headers`) whose values are redacted from debug logs, in addition to Authorization, Cookie, Proxy-Authorization, Set-Cookie, X-Api-Key and X-Auth-Token. Names are case-insensitive.",
+				},
+				"rate_limit_per_second": {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Default:      5,
+					Description:  "Maximum number of API requests per second for all endpoints except job creation. Requests above the limit wait instead of failing. Set to 0 to disable client-side throttling.",
+					ValidateFunc: validation.IntAtLeast(0),
+				},
+				"create_rate_limit_per_second": {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Default:      1,
+					Description:  "Maximum number of job creation requests (`PUT /jobs`) per second. Set to 0 to disable this limit.",
+					ValidateFunc: validation.IntAtLeast(0),
+				},
+				"create_rate_limit_per_minute": {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Default:      5,
+					Description:  "Maximum number of job creation requests (`PUT /jobs`) per minute. Set to 0 to disable this limit.",
+					ValidateFunc: validation.IntAtLeast(0),
+				},
+				"daily_request_budget": {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Default:      0,
+					Description:  "Maximum number of API requests per UTC day. Requests that would exceed the budget fail before being sent. Set this below the account's daily quota (100 by default, 5,000 for sustaining members). 0 = unlimited.",
+					ValidateFunc: validation.IntAtLeast(0),
+				},
+				"max_requests_per_run": {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Default:      0,
+					Description:  "Maximum number of API requests made by a single Terraform run. 0 = unlimited.",
+					ValidateFunc: validation.IntAtLeast(0),
+				},
+				"request_budget_warning_threshold": {
+					Type:         schema.TypeFloat,
+					Optional:     true,
+					Default:      client.DefaultBudgetWarningThreshold,
+					Description:  "Fraction of `daily_request_budget` after which a warning is reported.",
+					ValidateFunc: validation.FloatBetween(0, 1),
+				},
+				"request_budget_state_file": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					DefaultFunc: schema.EnvDefaultFunc("CRON_JOB_REQUEST_BUDGET_STATE_FILE", ""),
+					Description: "Path of a local file in which the daily request count is persisted per API key, so that `daily_request_budget` accounts for all runs of the day. Without it, only requests of the current run are counted. Concurrent runs share the file through a lock file next to it. Can also be set via CRON_JOB_REQUEST_BUDGET_STATE_FILE env variable.",
+				},
+				"incomplete_list_retries": {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Default:      client.DefaultIncompleteListRetries,
+					Description:  "Number of times a job listing is repeated when the API reports that some jobs could not be retrieved. Set to 0 to disable these retries.",
+					ValidateFunc: validation.IntAtLeast(0),
+				},
+				"bulk_refresh": {
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Default:     false,
+					Description: "Refresh `cronjoborg_job` resources from a single `GET /jobs` request per run instead of one request per job. `auth`, `notification` and `extended_data` are not part of the job list: they are read when a job is created, imported or updated, and sent with every update, but changes made to them outside of Terraform are not detected during refresh.",
+				},
 			},
-			"api_key": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Sensitive:   true,
-				DefaultFunc: schema.EnvDefaultFunc("CRON_JOB_API_KEY", nil),
-				Description: "API key for the cron-job API. Can also be set via CRON_JOB_API_KEY env variable.",
+			ResourcesMap: map[string]*schema.Resource{
+				"cronjoborg_job": resourceJob(),
 			},
-		},
-		ResourcesMap: map[string]*schema.Resource{
-			"cronjoborg_job": resourceJob(),
-		},
-		DataSourcesMap: map[string]*schema.Resource{
-			"cronjoborg_job":         dataSourceJob(),
-			"cronjoborg_jobs":        dataSourceJobs(),
-			"cronjoborg_job_history": dataSourceJobHistory(),
-		},
-		ConfigureContextFunc: providerConfigure,
+			DataSourcesMap: map[string]*schema.Resource{
+				"cronjoborg_job":              dataSourceJob(),
+				"cronjoborg_jobs":             dataSourceJobs(),
+				"cronjoborg_job_history":      dataSourceJobHistory(),
+				"cronjoborg_job_history_item": dataSourceJobHistoryItem(),
+				"cronjoborg_schedule_preview": dataSourceSchedulePreview(),
+			},
+		}
+		for _, r := range p.ResourcesMap {
+			withBudgetWarnings(r)
+		}
+		for _, r := range p.DataSourcesMap {
+			withBudgetWarnings(r)
+		}
+		p.ConfigureContextFunc = func(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
+			return providerConfigure(ctx, d, p.UserAgent("terraform-provider-cronjoborg", version))
+		}
+		return p
 	}
 }
 
-func providerConfigure(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
+// Provider returns the provider for development builds and tests.
+func Provider() *schema.Provider {
+	return New("dev")()
+}
+
+func providerConfigure(ctx context.Context, d *schema.ResourceData, userAgent string) (interface{}, diag.Diagnostics) {
 	apiUrl, ok := d.Get("api_url").(string)
 	if !ok {
 		return nil, diag.Errorf("api_url must be a string")
@@ -63,5 +193,171 @@ func providerConfigure(ctx context.Context, d *schema.ResourceData) (interface{}
 		return nil, diag.Errorf("API key must be provided via provider configuration or CRON_JOB_API_KEY environment variable")
 	}
 
-	return client.NewClient(apiUrl, apiKey), nil
+	retryMaxAttempts, ok := d.Get("retry_max_attempts").(int)
+	if !ok {
+		return nil, diag.Errorf("retry_max_attempts must be an integer")
+	}
+
+	retryMaxWaitSeconds, ok := d.Get("retry_max_wait_seconds").(int)
+	if !ok {
+		return nil, diag.Errorf("retry_max_wait_seconds must be an integer")
+	}
+
+	rateLimitPerSecond, ok := d.Get("rate_limit_per_second").(int)
+	if !ok {
+		return nil, diag.Errorf("rate_limit_per_second must be an integer")
+	}
+
+	createRateLimitPerSecond, ok := d.Get("create_rate_limit_per_second").(int)
+	if !ok {
+		return nil, diag.Errorf("create_rate_limit_per_second must be an integer")
+	}
+
+	createRateLimitPerMinute, ok := d.Get("create_rate_limit_per_minute").(int)
+	if !ok {
+		return nil, diag.Errorf("create_rate_limit_per_minute must be an integer")
+	}
+
+	dailyRequestBudget, ok := d.Get("daily_request_budget").(int)
+	if !ok {
+		return nil, diag.Errorf("daily_request_budget must be an integer")
+	}
+
+	maxRequestsPerRun, ok := d.Get("max_requests_per_run").(int)
+	if !ok {
+		return nil, diag.Errorf("max_requests_per_run must be an integer")
+	}
+
+	budgetWarningThreshold, ok := d.Get("request_budget_warning_threshold").(float64)
+	if !ok {
+		return nil, diag.Errorf("request_budget_warning_threshold must be a number")
+	}
+
+	budgetStateFile, ok := d.Get("request_budget_state_file").(string)
+	if !ok {
+		return nil, diag.Errorf("request_budget_state_file must be a string")
+	}
+
+	incompleteListRetries, ok := d.Get("incomplete_list_retries").(int)
+	if !ok {
+		return nil, diag.Errorf("incomplete_list_retries must be an integer")
+	}
+
+	bulkRefresh, ok := d.Get("bulk_refresh").(bool)
+	if !ok {
+		return nil, diag.Errorf("bulk_refresh must be a boolean")
+	}
+
+	requestTimeoutSeconds, ok := d.Get("request_timeout_seconds").(int)
+	if !ok {
+		return nil, diag.Errorf("request_timeout_seconds must be an integer")
+	}
+
+	proxyURL, ok := d.Get("proxy_url").(string)
+	if !ok {
+		return nil, diag.Errorf("proxy_url must be a string")
+	}
+
+	caCertFile, ok := d.Get("ca_cert_file").(string)
+	if !ok {
+		return nil, diag.Errorf("ca_cert_file must be a string")
+	}
+
+	insecureSkipVerify, ok := d.Get("insecure_skip_verify").(bool)
+	if !ok {
+		return nil, diag.Errorf("insecure_skip_verify must be a boolean")
+	}
+
+	sensitiveHeaderSet, ok := d.Get("sensitive_headers").(*schema.Set)
+	if !ok {
+		return nil, diag.Errorf("sensitive_headers must be a set")
+	}
+	var sensitiveHeaders []string
+	for _, v := range sensitiveHeaderSet.List() {
+		name, ok := v.(string)
+		if !ok {
+			return nil, diag.Errorf("sensitive_headers must contain strings")
+		}
+		sensitiveHeaders = append(sensitiveHeaders, name)
+	}
+
+	retry := client.DefaultRetryPolicy()
+	retry.MaxAttempts = retryMaxAttempts
+	retry.MaxWait = time.Duration(retryMaxWaitSeconds) * time.Second
+
+	opts := []client.Option{
+		client.WithRetry(retry),
+		client.WithTimeout(time.Duration(requestTimeoutSeconds) * time.Second),
+		client.WithUserAgent(userAgent),
+		client.WithInsecureSkipVerify(insecureSkipVerify),
+		client.WithSensitiveHeaders(sensitiveHeaders...),
+	}
+
+	if proxyURL != "" {
+		proxy, err := url.Parse(proxyURL)
+		if err != nil {
+			return nil, diag.Errorf("invalid proxy_url: %s", err)
+		}
+		opts = append(opts, client.WithProxy(proxy))
+	}
+
+	if caCertFile != "" {
+		pool, err := loadCACertFile(caCertFile)
+		if err != nil {
+			return nil, diag.FromErr(err)
+		}
+		opts = append(opts, client.WithRootCAs(pool))
+	}
+
+	var diags diag.Diagnostics
+	if insecureSkipVerify {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "TLS certificate verification is disabled",
+			Detail:   "insecure_skip_verify is set, so the API's TLS certificate is not verified and the API key can be intercepted. Use ca_cert_file to trust a custom CA instead.",
+		})
+	}
+
+	c := client.NewClient(apiUrl, apiKey, opts...)
+	c.IncompleteListRetries = incompleteListRetries
+	c.RateLimiter = client.NewRateLimiter(map[client.EndpointClass][]client.RateLimit{
+		client.EndpointClassDefault: {
+			{Requests: rateLimitPerSecond, Period: time.Second},
+		},
+		client.EndpointClassCreate: {
+			{Requests: createRateLimitPerSecond, Period: time.Second},
+			{Requests: createRateLimitPerMinute, Period: time.Minute},
+		},
+	})
+	if dailyRequestBudget > 0 || maxRequestsPerRun > 0 {
+		c.Quota = client.NewQuotaTracker(apiKey, client.QuotaBudget{
+			DailyLimit:       dailyRequestBudget,
+			MaxPerRun:        maxRequestsPerRun,
+			WarningThreshold: budgetWarningThreshold,
+			StateFile:        budgetStateFile,
+		})
+	}
+
+	if bulkRefresh {
+		c.JobCache = client.NewJobListCache()
+	}
+
+	return c, diags
+}
+
+// loadCACertFile returns the system's trusted CAs extended by the PEM certificates in path.
+func loadCACertFile(path string) (*x509.CertPool, error) {
+	pem, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read ca_cert_file: %w", err)
+	}
+
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("ca_cert_file %s does not contain any PEM certificates", path)
+	}
+	return pool, nil
 }
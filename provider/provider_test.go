@@ -4,11 +4,23 @@
 package provider
 
 import (
+	"bytes"
+	"context"
+	"encoding/pem"
+	"errors"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/plain-insure/terraform-provider-cronjoborg/client"
+	"github.com/plain-insure/terraform-provider-cronjoborg/internal/fakeapi"
 )
 
 func TestProvider(t *testing.T) {
@@ -73,6 +85,7 @@ func TestProvider(t *testing.T) {
 		"cronjoborg_job",
 		"cronjoborg_jobs",
 		"cronjoborg_job_history",
+		"cronjoborg_job_history_item",
 	}
 
 	for _, dataSource := range expectedDataSources {
@@ -206,3 +219,311 @@ func TestProvider_DefaultAPIURLHasNoTrailingSlash(t *testing.T) {
 		t.Errorf("Expected default API URL to be '%s', got '%s'", expectedDefault, defaultValue)
 	}
 }
+
+func TestProvider_ConfigureRetryPolicy(t *testing.T) {
+	p := Provider()
+
+	d := schema.TestResourceDataRaw(t, p.Schema, map[string]interface{}{
+		"api_key":                "test-api-key",
+		"retry_max_attempts":     2,
+		"retry_max_wait_seconds": 5,
+	})
+
+	meta, diags := p.ConfigureContextFunc(context.Background(), d)
+	if diags.HasError() {
+		t.Fatalf("Expected no errors, got %v", diags)
+	}
+
+	c, ok := meta.(*client.Client)
+	if !ok {
+		t.Fatalf("Expected *client.Client, got %T", meta)
+	}
+	if c.Retry.MaxAttempts != 2 {
+		t.Errorf("Expected MaxAttempts to be 2, got %d", c.Retry.MaxAttempts)
+	}
+	if c.Retry.MaxWait != 5*time.Second {
+		t.Errorf("Expected MaxWait to be 5s, got %v", c.Retry.MaxWait)
+	}
+	if c.Retry.MinWait != client.DefaultRetryMinWait {
+		t.Errorf("Expected MinWait to keep its default, got %v", c.Retry.MinWait)
+	}
+}
+
+func TestProvider_ConfigureRateLimiter(t *testing.T) {
+	p := Provider()
+
+	d := schema.TestResourceDataRaw(t, p.Schema, map[string]interface{}{
+		"api_key":                      "test-api-key",
+		"create_rate_limit_per_second": 0,
+		"create_rate_limit_per_minute": 0,
+	})
+
+	meta, diags := p.ConfigureContextFunc(context.Background(), d)
+	if diags.HasError() {
+		t.Fatalf("Expected no errors, got %v", diags)
+	}
+
+	c, ok := meta.(*client.Client)
+	if !ok {
+		t.Fatalf("Expected *client.Client, got %T", meta)
+	}
+	if c.RateLimiter == nil {
+		t.Fatal("Expected rate limiter to be configured")
+	}
+
+	// With both create limits disabled, creating jobs must never wait.
+	for i := 0; i < 10; i++ {
+		wait, err := c.RateLimiter.Wait(context.Background(), client.EndpointClassCreate)
+		if err != nil || wait != 0 {
+			t.Fatalf("Expected create requests to be unthrottled, got wait %v, err %v", wait, err)
+		}
+	}
+}
+
+func TestProvider_ConfigureRequestBudget(t *testing.T) {
+	p := Provider()
+
+	d := schema.TestResourceDataRaw(t, p.Schema, map[string]interface{}{
+		"api_key":              "test-api-key",
+		"max_requests_per_run": 1,
+	})
+
+	meta, diags := p.ConfigureContextFunc(context.Background(), d)
+	if diags.HasError() {
+		t.Fatalf("Expected no errors, got %v", diags)
+	}
+
+	c, ok := meta.(*client.Client)
+	if !ok {
+		t.Fatalf("Expected *client.Client, got %T", meta)
+	}
+	if c.Quota == nil {
+		t.Fatal("Expected request budget to be configured")
+	}
+	if err := c.Quota.Acquire(context.Background()); err != nil {
+		t.Fatalf("Expected first request to be allowed, got %v", err)
+	}
+	if err := c.Quota.Acquire(context.Background()); !errors.Is(err, client.ErrBudgetExhausted) {
+		t.Errorf("Expected ErrBudgetExhausted, got %v", err)
+	}
+}
+
+func TestProvider_RequestBudgetWarning(t *testing.T) {
+	server := fakeapi.NewServer(fakeapi.Options{})
+	defer server.Close()
+
+	p := Provider()
+	d := schema.TestResourceDataRaw(t, p.Schema, map[string]interface{}{
+		"api_url":                          server.URL,
+		"api_key":                          server.API.APIKey(),
+		"daily_request_budget":             4,
+		"request_budget_warning_threshold": 0.5,
+	})
+	meta, diags := p.ConfigureContextFunc(context.Background(), d)
+	if diags.HasError() {
+		t.Fatalf("Expected no errors, got %v", diags)
+	}
+
+	jobs := p.DataSourcesMap["cronjoborg_jobs"]
+	read := func() diag.Diagnostics {
+		return jobs.ReadContext(context.Background(), jobs.TestResourceData(), meta)
+	}
+
+	if diags := read(); len(diags) != 0 {
+		t.Fatalf("Expected no diagnostics below the threshold, got %v", diags)
+	}
+	diags = read()
+	if len(diags) != 1 || diags[0].Severity != diag.Warning || !strings.Contains(diags[0].Detail, "2 of 4") {
+		t.Fatalf("Expected a budget warning for 2 of 4 requests, got %v", diags)
+	}
+	if diags := read(); len(diags) != 0 {
+		t.Errorf("Expected the warning to be reported once, got %v", diags)
+	}
+}
+
+func TestProvider_ConfigureWithoutRequestBudget(t *testing.T) {
+	p := Provider()
+
+	d := schema.TestResourceDataRaw(t, p.Schema, map[string]interface{}{
+		"api_key": "test-api-key",
+	})
+
+	meta, diags := p.ConfigureContextFunc(context.Background(), d)
+	if diags.HasError() {
+		t.Fatalf("Expected no errors, got %v", diags)
+	}
+
+	if c, ok := meta.(*client.Client); !ok || c.Quota != nil {
+		t.Error("Expected request accounting to be disabled by default")
+	}
+}
+
+func TestEnumDescriptions(t *testing.T) {
+	expected := "HTTP request method (0=GET, 1=POST, 2=OPTIONS, 3=HEAD, 4=PUT, 5=DELETE, 6=TRACE, 7=CONNECT, 8=PATCH)"
+	if requestMethodDescription != expected {
+		t.Errorf("Expected %q, got %q", expected, requestMethodDescription)
+	}
+	if jobTypeDescription != "Job type (0=Default job, 1=Monitoring job)" {
+		t.Errorf("Unexpected job type description %q", jobTypeDescription)
+	}
+}
+
+func TestProvider_ConfigureJobListing(t *testing.T) {
+	p := Provider()
+
+	d := schema.TestResourceDataRaw(t, p.Schema, map[string]interface{}{
+		"api_key":                 "test-api-key",
+		"incomplete_list_retries": 0,
+		"bulk_refresh":            true,
+	})
+
+	meta, diags := p.ConfigureContextFunc(context.Background(), d)
+	if diags.HasError() {
+		t.Fatalf("Expected no errors, got %v", diags)
+	}
+
+	c, ok := meta.(*client.Client)
+	if !ok {
+		t.Fatalf("Expected *client.Client, got %T", meta)
+	}
+	if c.IncompleteListRetries != 0 {
+		t.Errorf("Expected IncompleteListRetries to be 0, got %d", c.IncompleteListRetries)
+	}
+	if c.JobCache == nil {
+		t.Error("Expected bulk_refresh to enable the job list cache")
+	}
+}
+
+func TestProvider_ConfigureHTTPClient(t *testing.T) {
+	p := New("1.2.3")()
+
+	d := schema.TestResourceDataRaw(t, p.Schema, map[string]interface{}{
+		"api_key":                 "test-api-key",
+		"request_timeout_seconds": 10,
+		"proxy_url":               "http://proxy.example.com:3128",
+	})
+
+	meta, diags := p.ConfigureContextFunc(context.Background(), d)
+	if diags.HasError() {
+		t.Fatalf("Expected no errors, got %v", diags)
+	}
+
+	c, ok := meta.(*client.Client)
+	if !ok {
+		t.Fatalf("Expected *client.Client, got %T", meta)
+	}
+	if c.HTTPClient.Timeout != 10*time.Second {
+		t.Errorf("Expected timeout 10s, got %v", c.HTTPClient.Timeout)
+	}
+	if !strings.Contains(c.UserAgent, "terraform-provider-cronjoborg/1.2.3") {
+		t.Errorf("Expected the User-Agent to contain the provider version, got %q", c.UserAgent)
+	}
+
+	transport, ok := c.HTTPClient.Transport.(*http.Transport)
+	if !ok {
+		t.Fatalf("Expected *http.Transport, got %T", c.HTTPClient.Transport)
+	}
+	req, _ := http.NewRequest(http.MethodGet, "https://api.cron-job.org/jobs", nil)
+	proxy, err := transport.Proxy(req)
+	if err != nil || proxy == nil || proxy.Host != "proxy.example.com:3128" {
+		t.Errorf("Expected requests to use the configured proxy, got %v, %v", proxy, err)
+	}
+}
+
+func TestProvider_ConfigureTLS(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"jobs": [], "someFailed": false}`))
+	}))
+	defer server.Close()
+
+	caCertFile := filepath.Join(t.TempDir(), "ca.pem")
+	caCert := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	if err := os.WriteFile(caCertFile, caCert, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	invalidCertFile := filepath.Join(t.TempDir(), "invalid.pem")
+	if err := os.WriteFile(invalidCertFile, []byte("not a certificate"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name        string
+		config      map[string]interface{}
+		wantErr     bool
+		wantWarning bool
+		wantListErr bool
+	}{
+		{name: "untrusted certificate", config: map[string]interface{}{}, wantListErr: true},
+		{name: "ca_cert_file", config: map[string]interface{}{"ca_cert_file": caCertFile}},
+		{name: "insecure_skip_verify", config: map[string]interface{}{"insecure_skip_verify": true}, wantWarning: true},
+		{name: "missing ca_cert_file", config: map[string]interface{}{"ca_cert_file": filepath.Join(t.TempDir(), "missing.pem")}, wantErr: true},
+		{name: "invalid ca_cert_file", config: map[string]interface{}{"ca_cert_file": invalidCertFile}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := Provider()
+			tt.config["api_url"] = server.URL
+			tt.config["api_key"] = "test-api-key"
+			tt.config["retry_max_attempts"] = 1
+			tt.config["rate_limit_per_second"] = 0
+
+			meta, diags := p.ConfigureContextFunc(context.Background(), schema.TestResourceDataRaw(t, p.Schema, tt.config))
+			if diags.HasError() != tt.wantErr {
+				t.Fatalf("Expected error %v, got %v", tt.wantErr, diags)
+			}
+			if tt.wantErr {
+				return
+			}
+			if hasWarning := len(diags) > 0; hasWarning != tt.wantWarning {
+				t.Errorf("Expected warning %v, got %v", tt.wantWarning, diags)
+			}
+
+			c, ok := meta.(*client.Client)
+			if !ok {
+				t.Fatalf("Expected *client.Client, got %T", meta)
+			}
+			if _, _, err := c.GetJobs(context.Background()); (err != nil) != tt.wantListErr {
+				t.Errorf("GetJobs() error = %v, wantErr %v", err, tt.wantListErr)
+			}
+		})
+	}
+}
+
+func TestProvider_ConfigureSensitiveHeaders(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	p := Provider()
+	d := schema.TestResourceDataRaw(t, p.Schema, map[string]interface{}{
+		"api_url":               server.URL,
+		"api_key":               "test-api-key",
+		"rate_limit_per_second": 0,
+		"sensitive_headers":     []interface{}{"x-signature"},
+	})
+
+	meta, diags := p.ConfigureContextFunc(context.Background(), d)
+	if diags.HasError() {
+		t.Fatalf("Expected no errors, got %v", diags)
+	}
+	c, ok := meta.(*client.Client)
+	if !ok {
+		t.Fatalf("Expected *client.Client, got %T", meta)
+	}
+
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+	patch := client.JobPatch{ExtendedData: &client.JobExtendedData{Headers: map[string]string{"X-Signature": "signature-secret"}}}
+	if err := c.UpdateJob(ctx, "1", patch); err != nil {
+		t.Fatal(err)
+	}
+
+	if strings.Contains(output.String(), "signature-secret") {
+		t.Errorf("Expected the X-Signature header to be redacted:\n%s", output.String())
+	}
+	if !strings.Contains(output.String(), "X-Signature") {
+		t.Errorf("Expected the request body to be logged:\n%s", output.String())
+	}
+}
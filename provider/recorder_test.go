@@ -0,0 +1,62 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/plain-insure/terraform-provider-cronjoborg/internal/fakeapi"
+	"github.com/plain-insure/terraform-provider-cronjoborg/internal/recorder"
+)
+
+// TestRecorder_ReplaysResourceLifecycle records a job lifecycle against the fake API and
+// replays it after the server is gone, as acceptance tests do with cassettes of the real API.
+func TestRecorder_ReplaysResourceLifecycle(t *testing.T) {
+	server := fakeapi.NewServer(fakeapi.Options{})
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	steps := []resource.TestStep{
+		{
+			Config: testFakeAPIProviderConfig(server) + `
+resource "cronjoborg_job" "test" {
+  title = "Recorded"
+  url   = "https://example.com/recorded"
+}
+`,
+			Check: resource.TestCheckResourceAttr("cronjoborg_job.test", "title", "Recorded"),
+		},
+	}
+
+	rec, err := recorder.New(recorder.ModeRecord, path, nil, server.API.APIKey())
+	if err != nil {
+		t.Fatal(err)
+	}
+	rec.RedactHeaders(idempotencyKeyHeader)
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { testFakeAPIPreCheck(t) },
+		ProviderFactories: testRecorderProviderFactories(rec),
+		Steps:             steps,
+	})
+	if err := rec.Stop(); err != nil {
+		t.Fatalf("Failed to save cassette: %v", err)
+	}
+	server.Close()
+
+	rec, err = recorder.New(recorder.ModeReplay, path, nil, server.API.APIKey())
+	if err != nil {
+		t.Fatal(err)
+	}
+	rec.RedactHeaders(idempotencyKeyHeader)
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { testFakeAPIPreCheck(t) },
+		ProviderFactories: testRecorderProviderFactories(rec),
+		Steps:             steps,
+	})
+	if err := rec.Stop(); err != nil {
+		t.Errorf("Replay diverged from the recording: %v", err)
+	}
+}
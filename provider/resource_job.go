@@ -4,56 +4,98 @@
 package provider
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"net/url"
+	"strconv"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/plain-insure/terraform-provider-cronjoborg/client"
 )
 
-// suppressEquivalentScheduleArray treats empty arrays and [-1] as equivalent
-// This prevents Terraform from showing diffs when schedule fields are not specified.
+// suppressEquivalentScheduleArray treats schedule lists as sets: lists with the same
+// values in any order or with duplicates are equivalent, and so are empty lists and [-1].
+// This prevents perpetual diffs when the API returns the values sorted.
 func suppressEquivalentScheduleArray(k, oldStr, newStr string, d *schema.ResourceData) bool {
-	// Get the old and new values as lists
-	oldVal, newVal := d.GetChange(k)
+	// The lists are derived from the cron expression if one is configured.
+	if scheduleAttributeConfigured(d, "cron_expression") {
+		return true
+	}
+
+	// k addresses the length or an element of the list, e.g. schedule.0.hours.#.
+	listKey := k[:strings.LastIndex(k, ".")]
+	oldVal, newVal := d.GetChange(listKey)
+	if configured, ok := scheduleListConfig(d, listKey[strings.LastIndex(listKey, ".")+1:]); ok {
+		newVal = configured
+	}
 
 	oldList, oldOk := oldVal.([]interface{})
 	newList, newOk := newVal.([]interface{})
-
-	// If either is not a list, don't suppress
 	if !oldOk || !newOk {
 		return false
 	}
+	return scheduleListsEquivalent(oldList, newList)
+}
 
-	// Helper to determine if list represents default
-	isDefault := func(list []interface{}) bool {
-		if len(list) == 0 {
-			return true
-		}
-		if len(list) == 1 {
-			if v, ok := list[0].(int); ok && v == -1 {
-				return true
-			}
-		}
-		return false
+// scheduleListFields are the schedule fields that cron_expression replaces.
+var scheduleListFields = []string{
+	"schedule.0.hours",
+	"schedule.0.mdays",
+	"schedule.0.minutes",
+	"schedule.0.months",
+	"schedule.0.wdays",
+}
+
+// validateCronExpression checks that a cron expression can be parsed.
+func validateCronExpression(v interface{}, k string) ([]string, []error) {
+	value, ok := v.(string)
+	if !ok {
+		return nil, []error{fmt.Errorf("expected %q to be a string", k)}
 	}
+	if _, err := client.ParseCronExpression(value); err != nil {
+		return nil, []error{fmt.Errorf("%q: %w", k, err)}
+	}
+	return nil, nil
+}
 
-	// Check if old/new are empty or sentinel [-1]
-	oldIsDefault := isDefault(oldList)
-	newIsDefault := isDefault(newList)
+// suppressEquivalentCronExpression treats cron expressions that run at the same
+// times as equal, e.g. "0 9 * * MON-FRI" and "0 9 * * 1-5".
+func suppressEquivalentCronExpression(k, oldStr, newStr string, d *schema.ResourceData) bool {
+	if oldStr == "" || newStr == "" {
+		return oldStr == newStr
+	}
+	schedule, err := client.ParseCronExpression(oldStr)
+	if err != nil {
+		return false
+	}
+	return cronExpressionMatches(newStr, schedule)
+}
 
-	// Suppress if both are default values
-	return oldIsDefault && newIsDefault
+// cronExpressionMatches reports whether expr runs at the times of schedule.
+func cronExpressionMatches(expr string, schedule client.JobSchedule) bool {
+	parsed, err := client.ParseCronExpression(expr)
+	if err != nil {
+		return false
+	}
+	return parsed.CronExpression() == schedule.CronExpression()
 }
 
 func resourceJob() *schema.Resource {
-	return &schema.Resource{
-		Create: resourceJobCreate,
-		Read:   resourceJobRead,
-		Update: resourceJobUpdate,
-		Delete: resourceJobDelete,
+	r := &schema.Resource{
+		CreateContext: resourceJobCreate,
+		ReadContext:   resourceJobRead,
+		UpdateContext: resourceJobUpdate,
+		DeleteContext: resourceJobDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceJobImport,
+		},
+		SchemaVersion:                  1,
+		CustomizeDiff:                  customizeDiffScheduleLint,
+		ValidateRawResourceConfigFuncs: []schema.ValidateRawResourceConfigFunc{validateScheduleLintWarnings},
 		Schema: map[string]*schema.Schema{
 			"title": {
 				Type:         schema.TypeString,
@@ -113,28 +155,55 @@ func resourceJob() *schema.Resource {
 				Type:         schema.TypeInt,
 				Optional:     true,
 				Default:      0,
-				Description:  "HTTP request method (0=GET, 1=POST, 2=OPTIONS, 3=HEAD, 4=PUT, 5=DELETE, 6=TRACE, 7=CONNECT, 8=PATCH)",
-				ValidateFunc: validation.IntBetween(0, 8),
+				Description:  requestMethodDescription,
+				ValidateFunc: validation.IntBetween(int(client.RequestMethodGet), int(client.RequestMethodPatch)),
 			},
 			"schedule": {
 				Type:        schema.TypeList,
 				Optional:    true,
 				MaxItems:    1,
-				Description: "Job schedule configuration",
+				Description: "Job schedule configuration. Omitting the block runs the job every minute. Schedules that can never run are rejected when planning",
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"timezone": {
-							Type:        schema.TypeString,
-							Optional:    true,
-							Default:     "UTC",
-							Description: "Schedule time zone",
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      "UTC",
+							Description:  "Schedule time zone as an IANA time zone name, e.g. `Europe/Berlin`",
+							ValidateFunc: validateTimezone,
 						},
 						"expires_at": {
-							Type:         schema.TypeInt,
-							Optional:     true,
-							Default:      0,
-							Description:  "Date/time after which the job expires (format: YYYYMMDDhhmmss, 0 = does not expire)",
-							ValidateFunc: validation.IntAtLeast(0),
+							Type:             schema.TypeInt,
+							Optional:         true,
+							Default:          0,
+							Description:      "Date/time in the schedule's time zone after which the job expires (format: YYYYMMDDhhmmss, 0 = does not expire)",
+							ValidateFunc:     validateExpiresAt,
+							DiffSuppressFunc: suppressExpiresAtFromRFC3339,
+						},
+						"expires_at_rfc3339": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Description: "Date/time after which the job expires as an RFC3339 timestamp, e.g. `2025-12-31T23:59:00Z`, " +
+								"to use instead of `expires_at`. It is converted into the schedule's time zone",
+							ConflictsWith:    []string{"schedule.0.expires_at"},
+							ValidateFunc:     validation.IsRFC3339Time,
+							DiffSuppressFunc: suppressEquivalentRFC3339,
+						},
+						"expires_at_human": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Human-readable expiry in the schedule's time zone, e.g. `2025-12-31 23:59:00 CET`, or `never`",
+						},
+						"cron_expression": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Description: "Five-field cron expression (minute, hour, day of month, month, day of week) to use instead of " +
+								"`hours`, `mdays`, `minutes`, `months` and `wdays`, e.g. `*/15 9-17 * * MON-FRI`. Lists, ranges, steps, " +
+								"month and weekday names and the macros `@yearly`, `@monthly`, `@weekly`, `@daily` and `@hourly` are supported. " +
+								"Expressions that restrict both the day of month and the day of week are rejected, as cron-job.org only runs jobs on days that match both",
+							ConflictsWith:    scheduleListFields,
+							ValidateFunc:     validateCronExpression,
+							DiffSuppressFunc: suppressEquivalentCronExpression,
 						},
 						"hours": {
 							Type:             schema.TypeList,
@@ -256,7 +325,7 @@ func resourceJob() *schema.Resource {
 						"headers": {
 							Type:        schema.TypeMap,
 							Optional:    true,
-							Description: "Request headers (key-value dictionary)",
+							Description: "Request headers (key-value dictionary). With `recover_failed_creates`, the job is created with an additional `X-Cronjoborg-Idempotency-Key` header, which is used to recover the job when the create request fails and is removed once the job has been created",
 							Elem: &schema.Schema{
 								Type: schema.TypeString,
 							},
@@ -270,6 +339,31 @@ func resourceJob() *schema.Resource {
 					},
 				},
 			},
+			"on_conflict": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  onConflictCreate,
+				Description: "What to do on create if a job matching `conflict_match` already exists: `create` another job, " +
+					"`adopt` the existing job by overwriting its settings with this configuration, or fail with an `error`. " +
+					"Adopting fails if several jobs match. Has no effect after the job has been created",
+				ValidateFunc: validation.StringInSlice([]string{onConflictCreate, onConflictAdopt, onConflictError}, false),
+			},
+			"conflict_match": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      conflictMatchTitleAndURL,
+				Description:  "How `on_conflict` finds existing jobs: by `title_and_url`, by `title` only or by `url` only",
+				ValidateFunc: validation.StringInSlice([]string{conflictMatchTitleAndURL, conflictMatchTitle, conflictMatchURL}, false),
+			},
+			"recover_failed_creates": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+				Description: "Find and adopt the job if a create request fails in a way that leaves open whether the API created it, " +
+					"instead of failing and leaving a job behind that the next apply duplicates. The job is created with a random `X-Cronjoborg-Idempotency-Key` header " +
+					"to recognize it, which is sent to `url` until an additional API request removes it right after the create. " +
+					"That request counts against `max_requests_per_run`. Has no effect after the job has been created",
+			},
 			// Computed fields for read-only values
 			"job_id": {
 				Type:        schema.TypeInt,
@@ -279,195 +373,111 @@ func resourceJob() *schema.Resource {
 			"type": {
 				Type:        schema.TypeInt,
 				Computed:    true,
-				Description: "Job type (0=Default job, 1=Monitoring job)",
+				Description: jobTypeDescription,
 			},
 		},
 	}
-}
-
-func resourceJobCreate(d *schema.ResourceData, m interface{}) error {
-	c, ok := m.(*client.Client)
-	if !ok {
-		return fmt.Errorf("expected *client.Client, got %T", m)
-	}
-
-	// Build job object from schema
-	job := make(map[string]interface{})
-
-	// Required fields
-	title, ok := d.Get("title").(string)
-	if !ok {
-		return fmt.Errorf("title must be a string")
-	}
-	job["title"] = title
 
-	url, ok := d.Get("url").(string)
-	if !ok {
-		return fmt.Errorf("url must be a string")
+	// Version 1 only normalizes the schedule lists, so both versions share the schema.
+	r.StateUpgraders = []schema.StateUpgrader{
+		{
+			Version: 0,
+			Type:    r.CoreConfigSchema().ImpliedType(),
+			Upgrade: resourceJobStateUpgradeV0,
+		},
 	}
-	job["url"] = url
 
-	// Optional fields with defaults
-	enabled, ok := d.Get("enabled").(bool)
-	if !ok {
-		return fmt.Errorf("enabled must be a boolean")
-	}
-	job["enabled"] = enabled
+	return r
+}
 
-	saveResponses, ok := d.Get("save_responses").(bool)
+func resourceJobCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c, ok := m.(client.JobsAPI)
 	if !ok {
-		return fmt.Errorf("save_responses must be a boolean")
+		return diag.Errorf("expected client.JobsAPI, got %T", m)
 	}
-	job["saveResponses"] = saveResponses
 
-	requestTimeout, ok := d.Get("request_timeout").(int)
-	if !ok {
-		return fmt.Errorf("request_timeout must be an integer")
+	job, err := expandJobCreate(d)
+	if err != nil {
+		return diag.FromErr(err)
 	}
-	job["requestTimeout"] = requestTimeout
 
-	redirectSuccess, ok := d.Get("redirect_success").(bool)
-	if !ok {
-		return fmt.Errorf("redirect_success must be a boolean")
+	// Take over an existing job instead of creating a duplicate if on_conflict asks to.
+	existingID, diags := resolveConflict(ctx, c, d, job)
+	if diags.HasError() {
+		return diags
 	}
-	job["redirectSuccess"] = redirectSuccess
-
-	folderId, ok := d.Get("folder_id").(int)
-	if !ok {
-		return fmt.Errorf("folder_id must be an integer")
+	if existingID != "" {
+		if diags := adoptJob(ctx, c, existingID, job); diags.HasError() {
+			return diags
+		}
+		d.SetId(existingID)
+		return resourceJobRead(ctx, d, m)
 	}
-	job["folderId"] = folderId
 
-	requestMethod, ok := d.Get("request_method").(int)
-	if !ok {
-		return fmt.Errorf("request_method must be an integer")
+	// Tag the job so it can be told apart from other jobs if the create request fails
+	// ambiguously. Untagged jobs save the request that removes the tag again.
+	recoverFailed, _ := d.Get("recover_failed_creates").(bool)
+	var key string
+	if recoverFailed {
+		key, err = newIdempotencyKey()
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		setIdempotencyKey(&job, key)
 	}
-	job["requestMethod"] = requestMethod
 
-	// Schedule - always include schedule with default values
-	schedule, err := buildScheduleFromResourceData(d)
+	id, err := c.CreateJob(ctx, job)
+	jobID := strconv.Itoa(id)
 	if err != nil {
-		return err
-	}
-	job["schedule"] = schedule
-
-	// Auth
-	if authListRaw := d.Get("auth"); authListRaw != nil {
-		authList, ok := authListRaw.([]interface{})
-		if !ok {
-			return fmt.Errorf("auth must be a list")
+		if !isAmbiguousCreateError(err) {
+			return apiErrorDiag("Error creating cron job", err)
 		}
-		if len(authList) > 0 {
-			authMap, ok := authList[0].(map[string]interface{})
-			if !ok {
-				return fmt.Errorf("auth element must be a map")
-			}
-			enable, ok := authMap["enable"].(bool)
-			if !ok {
-				return fmt.Errorf("auth.enable must be a boolean")
-			}
-			user, ok := authMap["user"].(string)
-			if !ok {
-				return fmt.Errorf("auth.user must be a string")
-			}
-			password, ok := authMap["password"].(string)
-			if !ok {
-				return fmt.Errorf("auth.password must be a string")
-			}
-			auth := map[string]interface{}{
-				"enable":   enable,
-				"user":     user,
-				"password": password,
-			}
-			job["auth"] = auth
+		if !recoverFailed {
+			return maybeCreatedDiag(err, "it was not looked up because `recover_failed_creates` is disabled", job)
 		}
-	}
-
-	// Notification
-	if notificationListRaw := d.Get("notification"); notificationListRaw != nil {
-		notificationList, ok := notificationListRaw.([]interface{})
-		if !ok {
-			return fmt.Errorf("notification must be a list")
+		if ctx.Err() != nil {
+			// Looking the job up would fail as well.
+			return maybeCreatedDiag(err, fmt.Sprintf("looking it up failed: %s", ctx.Err()), job)
 		}
-		if len(notificationList) > 0 {
-			notificationMap, ok := notificationList[0].(map[string]interface{})
-			if !ok {
-				return fmt.Errorf("notification element must be a map")
-			}
-			onFailure, ok := notificationMap["on_failure"].(bool)
-			if !ok {
-				return fmt.Errorf("notification.on_failure must be a boolean")
-			}
-			onSuccess, ok := notificationMap["on_success"].(bool)
-			if !ok {
-				return fmt.Errorf("notification.on_success must be a boolean")
-			}
-			onDisable, ok := notificationMap["on_disable"].(bool)
-			if !ok {
-				return fmt.Errorf("notification.on_disable must be a boolean")
-			}
-			notification := map[string]interface{}{
-				"onFailure": onFailure,
-				"onSuccess": onSuccess,
-				"onDisable": onDisable,
-			}
-			job["notification"] = notification
-		}
-	}
 
-	// Extended Data
-	if extendedDataListRaw := d.Get("extended_data"); extendedDataListRaw != nil {
-		extendedDataList, ok := extendedDataListRaw.([]interface{})
-		if !ok {
-			return fmt.Errorf("extended_data must be a list")
+		// The job may have been created although the request failed. Adopt it
+		// instead of leaving it behind and creating a duplicate on the next apply.
+		foundID, found, lookupErr := findCreatedJob(ctx, c, job, key)
+		if lookupErr != nil {
+			return maybeCreatedDiag(err, fmt.Sprintf("looking it up failed: %s", lookupErr), job)
 		}
-		if len(extendedDataList) > 0 {
-			extendedDataMap, ok := extendedDataList[0].(map[string]interface{})
-			if !ok {
-				return fmt.Errorf("extended_data element must be a map")
-			}
-			extendedData := make(map[string]interface{})
-
-			if headersRaw, exists := extendedDataMap["headers"]; exists {
-				headers, ok := headersRaw.(map[string]interface{})
-				if !ok {
-					return fmt.Errorf("extended_data.headers must be a map")
-				}
-				if len(headers) > 0 {
-					stringHeaders := make(map[string]string)
-					for k, v := range headers {
-						vStr, ok := v.(string)
-						if !ok {
-							return fmt.Errorf("extended_data.headers values must be strings")
-						}
-						stringHeaders[k] = vStr
-					}
-					extendedData["headers"] = stringHeaders
-				} else {
-					extendedData["headers"] = map[string]string{}
-				}
-			} else {
-				extendedData["headers"] = map[string]string{}
-			}
-
-			if bodyRaw, exists := extendedDataMap["body"]; exists {
-				body, ok := bodyRaw.(string)
-				if !ok {
-					return fmt.Errorf("extended_data.body must be a string")
-				}
-				extendedData["body"] = body
-			}
-			job["extendedData"] = extendedData
+		if !found {
+			return apiErrorDiag("Error creating cron job", err)
 		}
+
+		jobID = foundID
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "Recovered cron job after a failed create request",
+			Detail:   fmt.Sprintf("Creating the cron job failed with: %s\n\nThe API had created the job anyway; it is managed as job %s.", err, jobID),
+		})
 	}
 
-	jobID, err := c.CreateJob(job)
+	d.SetId(jobID)
+
+	// The job details endpoint may not find a job right after it was created.
+	jobDetails, err := readCreatedJob(ctx, c, jobID)
 	if err != nil {
-		return err
+		return append(diags, apiErrorDiag(fmt.Sprintf("Error reading cron job %s", jobID), err)...)
 	}
 
-	d.SetId(fmt.Sprintf("%d", jobID))
-	return resourceJobRead(d, m)
+	// The key is only needed while the create request is pending.
+	if recoverFailed {
+		if err := removeIdempotencyKey(ctx, c, jobID, jobDetails); err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  "Failed to remove the idempotency header",
+				Detail: fmt.Sprintf("The cron job %s was created, but removing its %s header failed: %s\n\n"+
+					"cron-job.org sends the header with every execution until `extended_data` is changed.", jobID, idempotencyKeyHeader, err),
+			})
+		}
+	}
+	return append(diags, setDetailedJob(d, jobDetails)...)
 }
 
 // normalizeScheduleSlice converts a schedule slice that uses the API default sentinel [-1]
@@ -481,70 +491,127 @@ func normalizeScheduleSlice(v []int) []int {
 	return v
 }
 
-func resourceJobRead(d *schema.ResourceData, m interface{}) error {
-	c, ok := m.(*client.Client)
+func resourceJobRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c, ok := m.(client.JobsAPI)
 	if !ok {
-		return fmt.Errorf("expected *client.Client, got %T", m)
+		return diag.Errorf("expected client.JobsAPI, got %T", m)
+	}
+
+	// In bulk refresh mode, jobs that have been read before are refreshed from the
+	// shared job list, keeping auth, notification and extended_data from state. Jobs
+	// that are new, imported, just written or missing from the list are read in full.
+	if jobID, _ := d.Get("job_id").(int); jobID != 0 {
+		job, listed, err := c.ListedJob(ctx, d.Id())
+		if err != nil {
+			return apiErrorDiag(fmt.Sprintf("Error listing cron jobs to refresh %s", d.Id()), err)
+		}
+		if listed {
+			return setJob(d, job)
+		}
 	}
 
-	jobDetails, err := c.GetJobDetails(d.Id())
+	jobDetails, err := c.GetJobDetails(ctx, d.Id())
 	if err != nil {
-		if apiErr, ok := err.(*client.APIError); ok && apiErr.StatusCode == 404 {
+		if errors.Is(err, client.ErrNotFound) {
+			// A job that has never been read (e.g. right after import) must
+			// exist; only drop it from state if it disappeared after being managed.
+			if jobID, _ := d.Get("job_id").(int); jobID == 0 {
+				return apiErrorDiag(fmt.Sprintf("Cron job %s not found", d.Id()), err)
+			}
 			d.SetId("")
 			return nil
 		}
-		return err
+		return apiErrorDiag(fmt.Sprintf("Error reading cron job %s", d.Id()), err)
+	}
+
+	return setDetailedJob(d, jobDetails)
+}
+
+// setDetailedJob sets all attributes of the job.
+func setDetailedJob(d *schema.ResourceData, jobDetails *client.DetailedJob) diag.Diagnostics {
+	if diags := setJob(d, &jobDetails.Job); diags.HasError() {
+		return diags
 	}
+	return setJobDetails(d, jobDetails)
+}
 
-	// Set all fields from the detailed job
-	if err := d.Set("job_id", jobDetails.JobID); err != nil {
-		return fmt.Errorf("error setting job_id: %w", err)
+// setJob sets the attributes that are included in the job list.
+func setJob(d *schema.ResourceData, job *client.Job) diag.Diagnostics {
+	if err := d.Set("job_id", job.JobID); err != nil {
+		return diag.Errorf("error setting job_id: %s", err)
 	}
-	if err := d.Set("title", jobDetails.Title); err != nil {
-		return fmt.Errorf("error setting title: %w", err)
+	if err := d.Set("title", job.Title); err != nil {
+		return diag.Errorf("error setting title: %s", err)
 	}
-	if err := d.Set("url", jobDetails.URL); err != nil {
-		return fmt.Errorf("error setting url: %w", err)
+	if err := d.Set("url", job.URL); err != nil {
+		return diag.Errorf("error setting url: %s", err)
 	}
-	if err := d.Set("enabled", jobDetails.Enabled); err != nil {
-		return fmt.Errorf("error setting enabled: %w", err)
+	if err := d.Set("enabled", job.Enabled); err != nil {
+		return diag.Errorf("error setting enabled: %s", err)
 	}
-	if err := d.Set("save_responses", jobDetails.SaveResponses); err != nil {
-		return fmt.Errorf("error setting save_responses: %w", err)
+	if err := d.Set("save_responses", job.SaveResponses); err != nil {
+		return diag.Errorf("error setting save_responses: %s", err)
 	}
-	if err := d.Set("type", jobDetails.Type); err != nil {
-		return fmt.Errorf("error setting type: %w", err)
+	if err := d.Set("type", int(job.Type)); err != nil {
+		return diag.Errorf("error setting type: %s", err)
 	}
-	if err := d.Set("request_timeout", jobDetails.RequestTimeout); err != nil {
-		return fmt.Errorf("error setting request_timeout: %w", err)
+	if err := d.Set("request_timeout", job.RequestTimeout); err != nil {
+		return diag.Errorf("error setting request_timeout: %s", err)
 	}
-	if err := d.Set("redirect_success", jobDetails.RedirectSuccess); err != nil {
-		return fmt.Errorf("error setting redirect_success: %w", err)
+	if err := d.Set("redirect_success", job.RedirectSuccess); err != nil {
+		return diag.Errorf("error setting redirect_success: %s", err)
 	}
-	if err := d.Set("folder_id", jobDetails.FolderID); err != nil {
-		return fmt.Errorf("error setting folder_id: %w", err)
+	if err := d.Set("folder_id", job.FolderID); err != nil {
+		return diag.Errorf("error setting folder_id: %s", err)
 	}
-	if err := d.Set("request_method", jobDetails.RequestMethod); err != nil {
-		return fmt.Errorf("error setting request_method: %w", err)
+	if err := d.Set("request_method", int(job.RequestMethod)); err != nil {
+		return diag.Errorf("error setting request_method: %s", err)
+	}
+
+	// Omitting the schedule block means the default schedule, so only set the block if
+	// it is configured or the job runs on another schedule, e.g. after an import.
+	configured, _ := d.Get("schedule").([]interface{})
+	if len(configured) == 0 && job.Schedule.Equal(defaultJobSchedule()) {
+		if err := d.Set("schedule", []interface{}{}); err != nil {
+			return diag.Errorf("error clearing schedule: %s", err)
+		}
+		return nil
+	}
+
+	// Keep a configured cron expression unless the job no longer runs at its times.
+	cronExpression, _ := d.Get("schedule.0.cron_expression").(string)
+	if cronExpression != "" && !cronExpressionMatches(cronExpression, job.Schedule) {
+		cronExpression = job.Schedule.CronExpression()
 	}
 
+	expiresAtRFC3339, _ := d.Get("schedule.0.expires_at_rfc3339").(string)
+
 	// Set schedule transforming API sentinels [-1] into empty slices so they
 	// compare equal with omitted configuration.
+	normalized := job.Schedule.Normalize()
 	schedule := []interface{}{
 		map[string]interface{}{
-			"timezone":   jobDetails.Schedule.Timezone,
-			"expires_at": jobDetails.Schedule.ExpiresAt,
-			"hours":      normalizeScheduleSlice(jobDetails.Schedule.Hours),
-			"mdays":      normalizeScheduleSlice(jobDetails.Schedule.MDays),
-			"minutes":    normalizeScheduleSlice(jobDetails.Schedule.Minutes),
-			"months":     normalizeScheduleSlice(jobDetails.Schedule.Months),
-			"wdays":      normalizeScheduleSlice(jobDetails.Schedule.WDays),
+			"timezone":           job.Schedule.Timezone,
+			"expires_at":         job.Schedule.ExpiresAt,
+			"expires_at_rfc3339": flattenExpiresAtRFC3339(expiresAtRFC3339, job.Schedule),
+			"expires_at_human":   humanExpiry(job.Schedule),
+			"cron_expression":    cronExpression,
+			"hours":              normalizeScheduleSlice(normalized.Hours),
+			"mdays":              normalizeScheduleSlice(normalized.MDays),
+			"minutes":            normalizeScheduleSlice(normalized.Minutes),
+			"months":             normalizeScheduleSlice(normalized.Months),
+			"wdays":              normalizeScheduleSlice(normalized.WDays),
 		},
 	}
 	if err := d.Set("schedule", schedule); err != nil {
-		return fmt.Errorf("error setting schedule: %w", err)
+		return diag.Errorf("error setting schedule: %s", err)
 	}
 
+	return nil
+}
+
+// setJobDetails sets the attributes that are only returned by the job details endpoint.
+func setJobDetails(d *schema.ResourceData, jobDetails *client.DetailedJob) diag.Diagnostics {
 	// Set auth only if it has non-default values
 	// Default is enable=false with empty user/password
 	// Treat whitespace-only user/password as empty.
@@ -557,425 +624,521 @@ func resourceJobRead(d *schema.ResourceData, m interface{}) error {
 			},
 		}
 		if err := d.Set("auth", auth); err != nil {
-			return fmt.Errorf("error setting auth: %w", err)
+			return diag.Errorf("error setting auth: %s", err)
 		}
 	} else {
 		// Clear auth block if it's all defaults
 		if err := d.Set("auth", []interface{}{}); err != nil {
-			return fmt.Errorf("error clearing auth: %w", err)
+			return diag.Errorf("error clearing auth: %s", err)
 		}
 	}
 
-	// Set notification
-	notification := []interface{}{
-		map[string]interface{}{
-			"on_failure": jobDetails.Notification.OnFailure,
-			"on_success": jobDetails.Notification.OnSuccess,
-			"on_disable": jobDetails.Notification.OnDisable,
-		},
-	}
-	if err := d.Set("notification", notification); err != nil {
-		return fmt.Errorf("error setting notification: %w", err)
+	// Set notification only if it has non-default values or is configured
+	// Default is all notifications disabled
+	configuredNotification, _ := d.Get("notification").([]interface{})
+	if jobDetails.Notification.OnFailure || jobDetails.Notification.OnSuccess || jobDetails.Notification.OnDisable || len(configuredNotification) > 0 {
+		notification := []interface{}{
+			map[string]interface{}{
+				"on_failure": jobDetails.Notification.OnFailure,
+				"on_success": jobDetails.Notification.OnSuccess,
+				"on_disable": jobDetails.Notification.OnDisable,
+			},
+		}
+		if err := d.Set("notification", notification); err != nil {
+			return diag.Errorf("error setting notification: %s", err)
+		}
+	} else {
+		// Clear notification block if it's all defaults
+		if err := d.Set("notification", []interface{}{}); err != nil {
+			return diag.Errorf("error clearing notification: %s", err)
+		}
 	}
 
 	// Set extended_data only if it has non-default values
 	// Default is empty headers and empty body
+	headers := withoutIdempotencyKey(jobDetails.ExtendedData.Headers)
 	bodyTrimmed := strings.TrimSpace(jobDetails.ExtendedData.Body)
-	if len(jobDetails.ExtendedData.Headers) > 0 || bodyTrimmed != "" {
+	if len(headers) > 0 || bodyTrimmed != "" {
 		extendedData := []interface{}{
 			map[string]interface{}{
-				"headers": jobDetails.ExtendedData.Headers,
+				"headers": headers,
 				"body":    bodyTrimmed,
 			},
 		}
 		if err := d.Set("extended_data", extendedData); err != nil {
-			return fmt.Errorf("error setting extended_data: %w", err)
+			return diag.Errorf("error setting extended_data: %s", err)
 		}
 	} else {
 		// Clear extended_data block if it's all defaults
 		if err := d.Set("extended_data", []interface{}{}); err != nil {
-			return fmt.Errorf("error clearing extended_data: %w", err)
+			return diag.Errorf("error clearing extended_data: %s", err)
 		}
 	}
 
 	return nil
 }
 
-func resourceJobUpdate(d *schema.ResourceData, m interface{}) error {
-	c, ok := m.(*client.Client)
+// resourceJobImport validates the job ID passed to `terraform import` before
+// handing it to resourceJobRead, which populates the rest of the state.
+func resourceJobImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	jobID, err := strconv.Atoi(strings.TrimSpace(d.Id()))
+	if err != nil || jobID <= 0 {
+		return nil, fmt.Errorf("invalid job ID %q: expected the numeric job ID shown in the cron-job.org console", d.Id())
+	}
+
+	d.SetId(strconv.Itoa(jobID))
+
+	// Provider-side settings are not stored in the API; assume their defaults.
+	if err := d.Set("on_conflict", onConflictCreate); err != nil {
+		return nil, err
+	}
+	if err := d.Set("conflict_match", conflictMatchTitleAndURL); err != nil {
+		return nil, err
+	}
+	if err := d.Set("recover_failed_creates", false); err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceJobUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c, ok := m.(client.JobsAPI)
 	if !ok {
-		return fmt.Errorf("expected *client.Client, got %T", m)
+		return diag.Errorf("expected client.JobsAPI, got %T", m)
 	}
 
-	// Build job object with only changed fields
-	job := make(map[string]interface{})
+	patch, err := expandJobPatch(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
-	// Check each field for changes
-	if d.HasChange("title") {
-		title, ok := d.Get("title").(string)
-		if !ok {
-			return fmt.Errorf("title must be a string")
+	// Only update if there are changes
+	if !patch.IsEmpty() {
+		// In bulk refresh mode the detail-only blocks in state may be outdated, so
+		// send them with every update to overwrite changes made outside of Terraform.
+		if c.JobCacheEnabled() {
+			if err := expandJobPatchDetails(d, &patch); err != nil {
+				return diag.FromErr(err)
+			}
 		}
-		job["title"] = title
-	}
-	if d.HasChange("url") {
-		url, ok := d.Get("url").(string)
-		if !ok {
-			return fmt.Errorf("url must be a string")
+
+		err := c.UpdateJob(ctx, d.Id(), patch)
+		if err != nil {
+			return apiErrorDiag(fmt.Sprintf("Error updating cron job %s", d.Id()), err)
 		}
-		job["url"] = url
 	}
-	if d.HasChange("enabled") {
-		enabled, ok := d.Get("enabled").(bool)
-		if !ok {
-			return fmt.Errorf("enabled must be a boolean")
-		}
-		job["enabled"] = enabled
+
+	return resourceJobRead(ctx, d, m)
+}
+
+func resourceJobDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c, ok := m.(client.JobsAPI)
+	if !ok {
+		return diag.Errorf("expected client.JobsAPI, got %T", m)
 	}
-	if d.HasChange("save_responses") {
-		saveResponses, ok := d.Get("save_responses").(bool)
-		if !ok {
-			return fmt.Errorf("save_responses must be a boolean")
-		}
-		job["saveResponses"] = saveResponses
+
+	err := c.DeleteJob(ctx, d.Id())
+	if err != nil {
+		return apiErrorDiag(fmt.Sprintf("Error deleting cron job %s", d.Id()), err)
 	}
-	if d.HasChange("request_timeout") {
-		requestTimeout, ok := d.Get("request_timeout").(int)
-		if !ok {
-			return fmt.Errorf("request_timeout must be an integer")
-		}
-		job["requestTimeout"] = requestTimeout
+
+	d.SetId("")
+	return nil
+}
+
+// expandJobCreate builds the create request payload from the resource configuration.
+func expandJobCreate(d jobData) (client.JobCreate, error) {
+	var job client.JobCreate
+
+	// Required fields
+	title, ok := d.Get("title").(string)
+	if !ok {
+		return job, fmt.Errorf("title must be a string")
 	}
-	if d.HasChange("redirect_success") {
-		redirectSuccess, ok := d.Get("redirect_success").(bool)
-		if !ok {
-			return fmt.Errorf("redirect_success must be a boolean")
-		}
-		job["redirectSuccess"] = redirectSuccess
+	job.Title = title
+
+	url, ok := d.Get("url").(string)
+	if !ok {
+		return job, fmt.Errorf("url must be a string")
 	}
-	if d.HasChange("folder_id") {
-		folderId, ok := d.Get("folder_id").(int)
-		if !ok {
-			return fmt.Errorf("folder_id must be an integer")
-		}
-		job["folderId"] = folderId
+	job.URL = url
+
+	// Optional fields with defaults
+	enabled, ok := d.Get("enabled").(bool)
+	if !ok {
+		return job, fmt.Errorf("enabled must be a boolean")
 	}
-	if d.HasChange("request_method") {
-		requestMethod, ok := d.Get("request_method").(int)
-		if !ok {
-			return fmt.Errorf("request_method must be an integer")
-		}
-		job["requestMethod"] = requestMethod
+	job.Enabled = enabled
+
+	saveResponses, ok := d.Get("save_responses").(bool)
+	if !ok {
+		return job, fmt.Errorf("save_responses must be a boolean")
 	}
+	job.SaveResponses = saveResponses
 
-	// Schedule
-	if d.HasChange("schedule") {
-		schedule, err := buildScheduleFromResourceData(d)
-		if err != nil {
-			return err
-		}
-		job["schedule"] = schedule
+	requestTimeout, ok := d.Get("request_timeout").(int)
+	if !ok {
+		return job, fmt.Errorf("request_timeout must be an integer")
 	}
+	job.RequestTimeout = requestTimeout
 
-	// Auth
-	if d.HasChange("auth") {
-		if authListRaw := d.Get("auth"); authListRaw != nil {
-			authList, ok := authListRaw.([]interface{})
-			if !ok {
-				return fmt.Errorf("auth must be a list")
-			}
-			if len(authList) > 0 {
-				authMap, ok := authList[0].(map[string]interface{})
-				if !ok {
-					return fmt.Errorf("auth element must be a map")
-				}
-				enable, ok := authMap["enable"].(bool)
-				if !ok {
-					return fmt.Errorf("auth.enable must be a boolean")
-				}
-				user, ok := authMap["user"].(string)
-				if !ok {
-					return fmt.Errorf("auth.user must be a string")
-				}
-				password, ok := authMap["password"].(string)
-				if !ok {
-					return fmt.Errorf("auth.password must be a string")
-				}
-				auth := map[string]interface{}{
-					"enable":   enable,
-					"user":     user,
-					"password": password,
-				}
-				job["auth"] = auth
-			}
-		}
+	redirectSuccess, ok := d.Get("redirect_success").(bool)
+	if !ok {
+		return job, fmt.Errorf("redirect_success must be a boolean")
 	}
+	job.RedirectSuccess = redirectSuccess
 
-	// Notification
-	if d.HasChange("notification") {
-		if notificationListRaw := d.Get("notification"); notificationListRaw != nil {
-			notificationList, ok := notificationListRaw.([]interface{})
-			if !ok {
-				return fmt.Errorf("notification must be a list")
-			}
-			if len(notificationList) > 0 {
-				notificationMap, ok := notificationList[0].(map[string]interface{})
-				if !ok {
-					return fmt.Errorf("notification element must be a map")
-				}
-				onFailure, ok := notificationMap["on_failure"].(bool)
-				if !ok {
-					return fmt.Errorf("notification.on_failure must be a boolean")
-				}
-				onSuccess, ok := notificationMap["on_success"].(bool)
-				if !ok {
-					return fmt.Errorf("notification.on_success must be a boolean")
-				}
-				onDisable, ok := notificationMap["on_disable"].(bool)
-				if !ok {
-					return fmt.Errorf("notification.on_disable must be a boolean")
-				}
-				notification := map[string]interface{}{
-					"onFailure": onFailure,
-					"onSuccess": onSuccess,
-					"onDisable": onDisable,
-				}
-				job["notification"] = notification
-			}
-		}
+	folderId, ok := d.Get("folder_id").(int)
+	if !ok {
+		return job, fmt.Errorf("folder_id must be an integer")
 	}
+	job.FolderID = folderId
 
-	// Extended Data
-	if d.HasChange("extended_data") {
-		if extendedDataListRaw := d.Get("extended_data"); extendedDataListRaw != nil {
-			extendedDataList, ok := extendedDataListRaw.([]interface{})
-			if !ok {
-				return fmt.Errorf("extended_data must be a list")
-			}
-			if len(extendedDataList) > 0 {
-				extendedDataMap, ok := extendedDataList[0].(map[string]interface{})
-				if !ok {
-					return fmt.Errorf("extended_data element must be a map")
-				}
-				extendedData := make(map[string]interface{})
-
-				if headersRaw, exists := extendedDataMap["headers"]; exists {
-					headers, ok := headersRaw.(map[string]interface{})
-					if !ok {
-						return fmt.Errorf("extended_data.headers must be a map")
-					}
-					if len(headers) > 0 {
-						stringHeaders := make(map[string]string)
-						for k, v := range headers {
-							vStr, ok := v.(string)
-							if !ok {
-								return fmt.Errorf("extended_data.headers values must be strings")
-							}
-							stringHeaders[k] = vStr
-						}
-						extendedData["headers"] = stringHeaders
-					} else {
-						extendedData["headers"] = map[string]string{}
-					}
-				} else {
-					extendedData["headers"] = map[string]string{}
-				}
+	requestMethod, ok := d.Get("request_method").(int)
+	if !ok {
+		return job, fmt.Errorf("request_method must be an integer")
+	}
+	job.RequestMethod = client.RequestMethod(requestMethod)
 
-				if bodyRaw, exists := extendedDataMap["body"]; exists {
-					body, ok := bodyRaw.(string)
-					if !ok {
-						return fmt.Errorf("extended_data.body must be a string")
-					}
-					extendedData["body"] = body
-				}
-				job["extendedData"] = extendedData
-			}
-		}
+	// Schedule - always include schedule with default values
+	schedule, err := buildScheduleFromResourceData(d)
+	if err != nil {
+		return job, err
 	}
+	job.Schedule = &schedule
 
-	// Only update if there are changes
-	if len(job) > 0 {
-		err := c.UpdateJob(d.Id(), job)
-		if err != nil {
+	if job.Auth, err = expandJobAuth(d); err != nil {
+		return job, err
+	}
+	if job.Notification, err = expandJobNotification(d); err != nil {
+		return job, err
+	}
+	if job.ExtendedData, err = expandJobExtendedData(d); err != nil {
+		return job, err
+	}
+
+	return job, nil
+}
+
+// expandJobPatch builds an update request payload containing only the fields changed in the plan.
+// Removing a block resets its settings to the API defaults.
+func expandJobPatch(d *schema.ResourceData) (client.JobPatch, error) {
+	oldJob, err := expandJobCreate(priorJobData{d})
+	if err != nil {
+		return client.JobPatch{}, err
+	}
+	newJob, err := expandJobCreate(d)
+	if err != nil {
+		return client.JobPatch{}, err
+	}
+	return client.DiffJobs(detailedJobFromCreate(oldJob), detailedJobFromCreate(newJob)), nil
+}
+
+// jobData is the part of *schema.ResourceData the expand functions read from.
+type jobData interface {
+	Get(key string) interface{}
+}
+
+// priorJobData reads the values of a resource before the planned changes.
+type priorJobData struct {
+	d *schema.ResourceData
+}
+
+// Get returns the prior value of key.
+func (p priorJobData) Get(key string) interface{} {
+	old, _ := p.d.GetChange(key)
+	return old
+}
+
+// detailedJobFromCreate returns the job settings of a create payload. Omitted
+// blocks are returned as their empty settings.
+func detailedJobFromCreate(job client.JobCreate) client.DetailedJob {
+	detailed := client.DetailedJob{
+		Job: client.Job{
+			Enabled:         job.Enabled,
+			Title:           job.Title,
+			SaveResponses:   job.SaveResponses,
+			URL:             job.URL,
+			RequestTimeout:  job.RequestTimeout,
+			RedirectSuccess: job.RedirectSuccess,
+			FolderID:        job.FolderID,
+			RequestMethod:   job.RequestMethod,
+		},
+	}
+	if job.Schedule != nil {
+		detailed.Schedule = *job.Schedule
+	}
+	if job.Auth != nil {
+		detailed.Auth = *job.Auth
+	}
+	if job.Notification != nil {
+		detailed.Notification = *job.Notification
+	}
+	if job.ExtendedData != nil {
+		detailed.ExtendedData = *job.ExtendedData
+	}
+	return detailed
+}
+
+// expandJobPatchDetails adds the configured auth, notification and extended_data
+// blocks to a patch that does not already contain them.
+func expandJobPatchDetails(d *schema.ResourceData, patch *client.JobPatch) error {
+	var err error
+	if patch.Auth == nil {
+		if patch.Auth, err = expandJobAuth(d); err != nil {
+			return err
+		}
+		if patch.Auth == nil {
+			patch.Auth = &client.JobAuth{}
+		}
+	}
+	if patch.Notification == nil {
+		if patch.Notification, err = expandJobNotification(d); err != nil {
+			return err
+		}
+		if patch.Notification == nil {
+			patch.Notification = &client.JobNotificationSettings{}
+		}
+	}
+	if patch.ExtendedData == nil {
+		if patch.ExtendedData, err = expandJobExtendedData(d); err != nil {
 			return err
 		}
+		if patch.ExtendedData == nil {
+			patch.ExtendedData = &client.JobExtendedData{}
+		}
+	}
+	return nil
+}
+
+// expandJobAuth returns the configured auth block, or nil if the block is omitted.
+func expandJobAuth(d jobData) (*client.JobAuth, error) {
+	authList, ok := d.Get("auth").([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("auth must be a list")
+	}
+	if len(authList) == 0 {
+		return nil, nil
+	}
+
+	authMap, ok := authList[0].(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("auth element must be a map")
+	}
+	enable, ok := authMap["enable"].(bool)
+	if !ok {
+		return nil, fmt.Errorf("auth.enable must be a boolean")
+	}
+	user, ok := authMap["user"].(string)
+	if !ok {
+		return nil, fmt.Errorf("auth.user must be a string")
+	}
+	password, ok := authMap["password"].(string)
+	if !ok {
+		return nil, fmt.Errorf("auth.password must be a string")
 	}
 
-	return resourceJobRead(d, m)
+	return &client.JobAuth{
+		Enable:   enable,
+		User:     user,
+		Password: password,
+	}, nil
 }
 
-func resourceJobDelete(d *schema.ResourceData, m interface{}) error {
-	c, ok := m.(*client.Client)
+// expandJobNotification returns the configured notification block, or nil if the block is omitted.
+func expandJobNotification(d jobData) (*client.JobNotificationSettings, error) {
+	notificationList, ok := d.Get("notification").([]interface{})
 	if !ok {
-		return fmt.Errorf("expected *client.Client, got %T", m)
+		return nil, fmt.Errorf("notification must be a list")
+	}
+	if len(notificationList) == 0 {
+		return nil, nil
 	}
 
-	err := c.DeleteJob(d.Id())
-	if err != nil {
-		return err
+	notificationMap, ok := notificationList[0].(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("notification element must be a map")
+	}
+	onFailure, ok := notificationMap["on_failure"].(bool)
+	if !ok {
+		return nil, fmt.Errorf("notification.on_failure must be a boolean")
+	}
+	onSuccess, ok := notificationMap["on_success"].(bool)
+	if !ok {
+		return nil, fmt.Errorf("notification.on_success must be a boolean")
+	}
+	onDisable, ok := notificationMap["on_disable"].(bool)
+	if !ok {
+		return nil, fmt.Errorf("notification.on_disable must be a boolean")
 	}
 
-	d.SetId("")
-	return nil
+	return &client.JobNotificationSettings{
+		OnFailure: onFailure,
+		OnSuccess: onSuccess,
+		OnDisable: onDisable,
+	}, nil
 }
 
-// buildScheduleFromResourceData extracts schedule configuration from resource data and applies defaults.
-func buildScheduleFromResourceData(d *schema.ResourceData) (map[string]interface{}, error) {
-	schedule := make(map[string]interface{})
+// expandJobExtendedData returns the configured extended_data block, or nil if the block is omitted.
+func expandJobExtendedData(d jobData) (*client.JobExtendedData, error) {
+	extendedDataList, ok := d.Get("extended_data").([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("extended_data must be a list")
+	}
+	if len(extendedDataList) == 0 {
+		return nil, nil
+	}
 
-	scheduleListRaw := d.Get("schedule")
-	if scheduleListRaw != nil {
-		scheduleList, ok := scheduleListRaw.([]interface{})
+	extendedDataMap, ok := extendedDataList[0].(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("extended_data element must be a map")
+	}
+	extendedData := &client.JobExtendedData{
+		Headers: map[string]string{},
+	}
+
+	if headersRaw, exists := extendedDataMap["headers"]; exists {
+		headers, ok := headersRaw.(map[string]interface{})
 		if !ok {
-			return nil, fmt.Errorf("schedule must be a list")
+			return nil, fmt.Errorf("extended_data.headers must be a map")
 		}
-		if len(scheduleList) > 0 {
-			scheduleMap, ok := scheduleList[0].(map[string]interface{})
+		for k, v := range headers {
+			vStr, ok := v.(string)
 			if !ok {
-				return nil, fmt.Errorf("schedule element must be a map")
+				return nil, fmt.Errorf("extended_data.headers values must be strings")
 			}
+			extendedData.Headers[k] = vStr
+		}
+	}
 
-			timezone, ok := scheduleMap["timezone"].(string)
-			if !ok {
-				return nil, fmt.Errorf("schedule.timezone must be a string")
-			}
-			schedule["timezone"] = timezone
+	if bodyRaw, exists := extendedDataMap["body"]; exists {
+		body, ok := bodyRaw.(string)
+		if !ok {
+			return nil, fmt.Errorf("extended_data.body must be a string")
+		}
+		extendedData.Body = body
+	}
 
-			expiresAt, ok := scheduleMap["expires_at"].(int)
-			if !ok {
-				return nil, fmt.Errorf("schedule.expires_at must be an integer")
-			}
-			schedule["expiresAt"] = expiresAt
-
-			if hoursRaw, exists := scheduleMap["hours"]; exists {
-				hours, ok := hoursRaw.([]interface{})
-				if !ok {
-					return nil, fmt.Errorf("schedule.hours must be a list")
-				}
-				if len(hours) > 0 {
-					hoursInt := make([]int, len(hours))
-					for i, v := range hours {
-						vInt, ok := v.(int)
-						if !ok {
-							return nil, fmt.Errorf("schedule.hours values must be integers")
-						}
-						hoursInt[i] = vInt
-					}
-					schedule["hours"] = hoursInt
-				} else {
-					schedule["hours"] = []int{-1}
-				}
-			} else {
-				schedule["hours"] = []int{-1}
-			}
+	return extendedData, nil
+}
 
-			if mdaysRaw, exists := scheduleMap["mdays"]; exists {
-				mdays, ok := mdaysRaw.([]interface{})
-				if !ok {
-					return nil, fmt.Errorf("schedule.mdays must be a list")
-				}
-				if len(mdays) > 0 {
-					mdaysInt := make([]int, len(mdays))
-					for i, v := range mdays {
-						vInt, ok := v.(int)
-						if !ok {
-							return nil, fmt.Errorf("schedule.mdays values must be integers")
-						}
-						mdaysInt[i] = vInt
-					}
-					schedule["mdays"] = mdaysInt
-				} else {
-					schedule["mdays"] = []int{-1}
-				}
-			} else {
-				schedule["mdays"] = []int{-1}
-			}
+// defaultJobSchedule returns the schedule sent when no schedule block is configured.
+func defaultJobSchedule() client.JobSchedule {
+	return client.JobSchedule{
+		Timezone:  "UTC",
+		ExpiresAt: 0,
+		Hours:     []int{-1},
+		MDays:     []int{-1},
+		Minutes:   []int{-1},
+		Months:    []int{-1},
+		WDays:     []int{-1},
+	}
+}
 
-			if minutesRaw, exists := scheduleMap["minutes"]; exists {
-				minutes, ok := minutesRaw.([]interface{})
-				if !ok {
-					return nil, fmt.Errorf("schedule.minutes must be a list")
-				}
-				if len(minutes) > 0 {
-					minutesInt := make([]int, len(minutes))
-					for i, v := range minutes {
-						vInt, ok := v.(int)
-						if !ok {
-							return nil, fmt.Errorf("schedule.minutes values must be integers")
-						}
-						minutesInt[i] = vInt
-					}
-					schedule["minutes"] = minutesInt
-				} else {
-					schedule["minutes"] = []int{-1}
-				}
-			} else {
-				schedule["minutes"] = []int{-1}
-			}
+// buildScheduleFromResourceData extracts schedule configuration from resource data, applies
+// defaults and sorts and deduplicates the time lists.
+func buildScheduleFromResourceData(d jobData) (client.JobSchedule, error) {
+	schedule := defaultJobSchedule()
 
-			if monthsRaw, exists := scheduleMap["months"]; exists {
-				months, ok := monthsRaw.([]interface{})
-				if !ok {
-					return nil, fmt.Errorf("schedule.months must be a list")
-				}
-				if len(months) > 0 {
-					monthsInt := make([]int, len(months))
-					for i, v := range months {
-						vInt, ok := v.(int)
-						if !ok {
-							return nil, fmt.Errorf("schedule.months values must be integers")
-						}
-						monthsInt[i] = vInt
-					}
-					schedule["months"] = monthsInt
-				} else {
-					schedule["months"] = []int{-1}
-				}
-			} else {
-				schedule["months"] = []int{-1}
-			}
+	scheduleList, ok := d.Get("schedule").([]interface{})
+	if !ok {
+		return schedule, fmt.Errorf("schedule must be a list")
+	}
+	if len(scheduleList) == 0 {
+		// No schedule block provided - use defaults
+		return schedule, nil
+	}
 
-			if wdaysRaw, exists := scheduleMap["wdays"]; exists {
-				wdays, ok := wdaysRaw.([]interface{})
-				if !ok {
-					return nil, fmt.Errorf("schedule.wdays must be a list")
-				}
-				if len(wdays) > 0 {
-					wdaysInt := make([]int, len(wdays))
-					for i, v := range wdays {
-						vInt, ok := v.(int)
-						if !ok {
-							return nil, fmt.Errorf("schedule.wdays values must be integers")
-						}
-						wdaysInt[i] = vInt
-					}
-					schedule["wdays"] = wdaysInt
-				} else {
-					schedule["wdays"] = []int{-1}
-				}
-			} else {
-				schedule["wdays"] = []int{-1}
-			}
-		} else {
-			// Empty schedule list - use defaults
-			schedule["timezone"] = "UTC"
-			schedule["expiresAt"] = 0
-			schedule["hours"] = []int{-1}
-			schedule["mdays"] = []int{-1}
-			schedule["minutes"] = []int{-1}
-			schedule["months"] = []int{-1}
-			schedule["wdays"] = []int{-1}
+	scheduleMap, ok := scheduleList[0].(map[string]interface{})
+	if !ok {
+		return schedule, fmt.Errorf("schedule element must be a map")
+	}
+	schedule, err := expandSchedule(scheduleMap)
+	if err != nil {
+		return schedule, err
+	}
+	return schedule.Normalize(), nil
+}
+
+// expandSchedule converts the attributes of a schedule block into a schedule.
+// Omitted time lists default to [-1].
+func expandSchedule(scheduleMap map[string]interface{}) (client.JobSchedule, error) {
+	schedule := defaultJobSchedule()
+
+	timezone, ok := scheduleMap["timezone"].(string)
+	if !ok {
+		return schedule, fmt.Errorf("schedule.timezone must be a string")
+	}
+	schedule.Timezone = timezone
+
+	expiresAt, ok := scheduleMap["expires_at"].(int)
+	if !ok {
+		return schedule, fmt.Errorf("schedule.expires_at must be an integer")
+	}
+	schedule.ExpiresAt = expiresAt
+
+	if expiresAtRFC3339, _ := scheduleMap["expires_at_rfc3339"].(string); expiresAtRFC3339 != "" {
+		expiresAt, err := expandExpiresAtRFC3339(expiresAtRFC3339, schedule)
+		if err != nil {
+			return schedule, err
 		}
-	} else {
-		// No schedule block provided - use defaults
-		schedule["timezone"] = "UTC"
-		schedule["expiresAt"] = 0
-		schedule["hours"] = []int{-1}
-		schedule["mdays"] = []int{-1}
-		schedule["minutes"] = []int{-1}
-		schedule["months"] = []int{-1}
-		schedule["wdays"] = []int{-1}
+		schedule.ExpiresAt = expiresAt
+	}
+
+	if cronExpression, _ := scheduleMap["cron_expression"].(string); cronExpression != "" {
+		times, err := client.ParseCronExpression(cronExpression)
+		if err != nil {
+			return schedule, err
+		}
+		schedule.Hours, schedule.MDays, schedule.Minutes, schedule.Months, schedule.WDays =
+			times.Hours, times.MDays, times.Minutes, times.Months, times.WDays
+		return schedule, nil
+	}
+
+	var err error
+	if schedule.Hours, err = expandScheduleList(scheduleMap, "hours"); err != nil {
+		return schedule, err
+	}
+	if schedule.MDays, err = expandScheduleList(scheduleMap, "mdays"); err != nil {
+		return schedule, err
+	}
+	if schedule.Minutes, err = expandScheduleList(scheduleMap, "minutes"); err != nil {
+		return schedule, err
+	}
+	if schedule.Months, err = expandScheduleList(scheduleMap, "months"); err != nil {
+		return schedule, err
+	}
+	if schedule.WDays, err = expandScheduleList(scheduleMap, "wdays"); err != nil {
+		return schedule, err
 	}
 
 	return schedule, nil
 }
+
+// expandScheduleList converts a schedule list field into integers. Omitted or empty
+// lists become the API sentinel [-1].
+func expandScheduleList(scheduleMap map[string]interface{}, field string) ([]int, error) {
+	raw, exists := scheduleMap[field]
+	if !exists {
+		return []int{-1}, nil
+	}
+
+	values, ok := raw.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("schedule.%s must be a list", field)
+	}
+	if len(values) == 0 {
+		return []int{-1}, nil
+	}
+
+	result := make([]int, len(values))
+	for i, v := range values {
+		vInt, ok := v.(int)
+		if !ok {
+			return nil, fmt.Errorf("schedule.%s values must be integers", field)
+		}
+		result[i] = vInt
+	}
+
+	return result, nil
+}
@@ -0,0 +1,101 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/plain-insure/terraform-provider-cronjoborg/client"
+	"github.com/plain-insure/terraform-provider-cronjoborg/internal/fakeapi"
+)
+
+func TestResourceJob_FakeAPI_BulkRefresh(t *testing.T) {
+	server := fakeapi.NewServer(fakeapi.Options{})
+	defer server.Close()
+
+	config := func(title string) string {
+		return testFakeAPIProviderConfig(server, "bulk_refresh = true") + fmt.Sprintf(`
+resource "cronjoborg_job" "test" {
+  count = 3
+  title = "%s ${count.index}"
+  url   = "https://example.com/${count.index}"
+
+  notification {
+    on_failure = true
+  }
+}
+`, title)
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { testFakeAPIPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testCheckFakeAPIJobsDestroyed(server),
+		Steps: []resource.TestStep{
+			{
+				Config: config("Job"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("cronjoborg_job.test.0", "notification.0.on_failure", "true"),
+					resource.TestCheckResourceAttr("cronjoborg_job.test.2", "title", "Job 2"),
+				),
+			},
+			{
+				// Refreshing unchanged jobs only lists them. A title changed in the
+				// console is detected from the list and reverted.
+				PreConfig: func() {
+					server.API.ResetRequests()
+					server.API.UpdateJob(2, func(job *client.DetailedJob) { job.Title = "Changed in console" })
+				},
+				Config: config("Job"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckFakeAPIJob(server, 2, func(job client.DetailedJob) error {
+						if !strings.HasPrefix(job.Title, "Job ") || !job.Notification.OnFailure {
+							return fmt.Errorf("expected the title to be reverted, got %+v", job)
+						}
+						return nil
+					}),
+					testCheckFakeAPIDetailRequests(server, []string{"GET /jobs/2"}),
+				),
+			},
+			{
+				// Detail-only settings are sent with every update.
+				PreConfig: func() {
+					server.API.UpdateJob(1, func(job *client.DetailedJob) { job.Notification.OnFailure = false })
+				},
+				Config: config("Renamed"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("cronjoborg_job.test.0", "title", "Renamed 0"),
+					resource.TestCheckResourceAttr("cronjoborg_job.test.1", "notification.0.on_failure", "true"),
+					testCheckFakeAPIJob(server, 1, func(job client.DetailedJob) error {
+						if !job.Notification.OnFailure {
+							return fmt.Errorf("expected notification settings to be restored, got %+v", job.Notification)
+						}
+						return nil
+					}),
+				),
+			},
+		},
+	})
+}
+
+// testCheckFakeAPIDetailRequests verifies that the only job detail requests since the
+// request log was last reset are the expected ones, in any order and with repetitions.
+func testCheckFakeAPIDetailRequests(server *fakeapi.Server, expected []string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		allowed := make(map[string]bool, len(expected))
+		for _, request := range expected {
+			allowed[request] = true
+		}
+		for _, request := range server.API.Requests() {
+			if strings.HasPrefix(request, "GET /jobs/") && !allowed[request] {
+				return fmt.Errorf("unexpected detail request %q, requests: %v", request, server.API.Requests())
+			}
+		}
+		return nil
+	}
+}
@@ -61,56 +61,31 @@ func TestSuppressEquivalentScheduleArray(t *testing.T) {
 			name:     "Same specific values",
 			oldVal:   []interface{}{5, 10},
 			newVal:   []interface{}{5, 10},
-			expected: false, // Not both default, so shouldn't suppress
+			expected: true,
+		},
+		{
+			name:     "Same values in a different order",
+			oldVal:   []interface{}{9, 17},
+			newVal:   []interface{}{17, 9},
+			expected: true,
+		},
+		{
+			name:     "Duplicate values",
+			oldVal:   []interface{}{9, 17},
+			newVal:   []interface{}{17, 9, 17},
+			expected: true,
+		},
+		{
+			name:     "Additional value",
+			oldVal:   []interface{}{9, 17},
+			newVal:   []interface{}{9, 12, 17},
+			expected: false,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			resource := resourceJob()
-			resourceData := resource.TestResourceData()
-
-			// We need to set up both the old and new state for GetChange to work
-			// First, set the old value and mark it as not new
-			schedule := []interface{}{
-				map[string]interface{}{
-					"timezone":   "UTC",
-					"expires_at": 0,
-					"hours":      tt.oldVal,
-				},
-			}
-			if err := resourceData.Set("schedule", schedule); err != nil {
-				t.Fatalf("Error setting old schedule: %v", err)
-			}
-
-			// Create a mock ResourceDiff to properly test GetChange
-			// Since we can't easily create a proper ResourceDiff with old/new states,
-			// we'll test the logic directly by creating a custom ResourceData wrapper
-			oldList := tt.oldVal
-			newList := tt.newVal
-
-			isDefault := func(list []interface{}) bool {
-				if len(list) == 0 {
-					return true
-				}
-				if len(list) == 1 {
-					if v, ok := list[0].(int); ok && v == -1 {
-						return true
-					}
-				}
-				return false
-			}
-
-			// Check if old is empty or [-1]
-			oldIsDefault := isDefault(oldList)
-
-			// Check if new is empty or [-1]
-			newIsDefault := isDefault(newList)
-
-			// Suppress if both are default values
-			result := oldIsDefault && newIsDefault
-
-			if result != tt.expected {
+			if result := scheduleListsEquivalent(tt.oldVal, tt.newVal); result != tt.expected {
 				t.Errorf("Expected %v, got %v for old=%v, new=%v", tt.expected, result, tt.oldVal, tt.newVal)
 			}
 		})
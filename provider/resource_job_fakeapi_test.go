@@ -0,0 +1,657 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"slices"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/plain-insure/terraform-provider-cronjoborg/client"
+	"github.com/plain-insure/terraform-provider-cronjoborg/internal/fakeapi"
+)
+
+func TestResourceJob_FakeAPI_CRUD(t *testing.T) {
+	server := fakeapi.NewServer(fakeapi.Options{})
+	defer server.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { testFakeAPIPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testCheckFakeAPIJobsDestroyed(server),
+		Steps: []resource.TestStep{
+			{
+				Config: testFakeAPIProviderConfig(server) + `
+resource "cronjoborg_job" "test" {
+  title   = "Backup"
+  url     = "https://example.com/backup"
+  enabled = true
+
+  schedule {
+    timezone = "Europe/Berlin"
+    hours    = [3]
+    minutes  = [15]
+  }
+
+  extended_data {
+    headers = {
+      "X-Token" = "secret"
+    }
+  }
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("cronjoborg_job.test", "id", "1"),
+					resource.TestCheckResourceAttr("cronjoborg_job.test", "job_id", "1"),
+					resource.TestCheckResourceAttr("cronjoborg_job.test", "title", "Backup"),
+					resource.TestCheckResourceAttr("cronjoborg_job.test", "schedule.0.hours.0", "3"),
+					resource.TestCheckResourceAttr("cronjoborg_job.test", "schedule.0.mdays.#", "0"),
+					resource.TestCheckResourceAttr("cronjoborg_job.test", "extended_data.0.headers.X-Token", "secret"),
+					testCheckFakeAPIJob(server, 1, func(job client.DetailedJob) error {
+						if !job.Enabled || job.URL != "https://example.com/backup" {
+							return fmt.Errorf("unexpected job settings: %+v", job.Job)
+						}
+						if job.Schedule.Timezone != "Europe/Berlin" || len(job.Schedule.MDays) != 1 || job.Schedule.MDays[0] != -1 {
+							return fmt.Errorf("unexpected schedule: %+v", job.Schedule)
+						}
+						return nil
+					}),
+				),
+			},
+			{
+				Config: testFakeAPIProviderConfig(server) + `
+resource "cronjoborg_job" "test" {
+  title = "Nightly backup"
+  url   = "https://example.com/backup"
+
+  schedule {
+    timezone = "Europe/Berlin"
+    hours    = [4]
+    minutes  = [15]
+  }
+
+  extended_data {
+    headers = {
+      "X-Token" = "secret"
+    }
+  }
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("cronjoborg_job.test", "id", "1"),
+					resource.TestCheckResourceAttr("cronjoborg_job.test", "title", "Nightly backup"),
+					resource.TestCheckResourceAttr("cronjoborg_job.test", "enabled", "false"),
+					testCheckFakeAPIJob(server, 1, func(job client.DetailedJob) error {
+						if job.Enabled || job.Title != "Nightly backup" || job.Schedule.Hours[0] != 4 {
+							return fmt.Errorf("update not applied: %+v", job.Job)
+						}
+						return nil
+					}),
+				),
+			},
+			{
+				ResourceName:      "cronjoborg_job.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestResourceJob_FakeAPI_NoIdempotencyHeader(t *testing.T) {
+	server := fakeapi.NewServer(fakeapi.Options{})
+	defer server.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { testFakeAPIPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testCheckFakeAPIJobsDestroyed(server),
+		Steps: []resource.TestStep{
+			{
+				Config: testFakeAPIProviderConfig(server) + `
+resource "cronjoborg_job" "plain" {
+  title = "Plain"
+  url   = "https://example.com/plain"
+}
+
+resource "cronjoborg_job" "headers" {
+  title = "Headers"
+  url   = "https://example.com/headers"
+
+  extended_data {
+    headers = {
+      "X-Token" = "secret"
+    }
+  }
+}
+
+resource "cronjoborg_job" "recover" {
+  title = "Recover"
+  url   = "https://example.com/recover"
+
+  recover_failed_creates = true
+}
+`,
+				Check: func(*terraform.State) error {
+					want := map[string]map[string]string{
+						"Plain":   {},
+						"Headers": {"X-Token": "secret"},
+						"Recover": {},
+					}
+					for _, job := range server.API.Jobs() {
+						if !reflect.DeepEqual(job.ExtendedData.Headers, want[job.Title]) {
+							return fmt.Errorf("expected job %q to be stored with headers %v, got %v", job.Title, want[job.Title], job.ExtendedData.Headers)
+						}
+					}
+					return nil
+				},
+			},
+		},
+	})
+}
+
+func TestResourceJob_FakeAPI_Drift(t *testing.T) {
+	server := fakeapi.NewServer(fakeapi.Options{})
+	defer server.Close()
+
+	config := testFakeAPIProviderConfig(server) + `
+resource "cronjoborg_job" "test" {
+  title = "Heartbeat"
+  url   = "https://example.com/heartbeat"
+}
+`
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { testFakeAPIPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testCheckFakeAPIJobsDestroyed(server),
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check:  resource.TestCheckResourceAttr("cronjoborg_job.test", "id", "1"),
+			},
+			{
+				// A change made in the console is reverted.
+				PreConfig: func() {
+					server.API.UpdateJob(1, func(job *client.DetailedJob) { job.Title = "Changed in console" })
+				},
+				Config: config,
+				Check: testCheckFakeAPIJob(server, 1, func(job client.DetailedJob) error {
+					if job.Title != "Heartbeat" {
+						return fmt.Errorf("expected title to be reverted, got %q", job.Title)
+					}
+					return nil
+				}),
+			},
+			{
+				// A job deleted in the console is recreated.
+				PreConfig: func() { server.API.DeleteJob(1) },
+				Config:    config,
+				Check:     resource.TestCheckResourceAttr("cronjoborg_job.test", "id", "2"),
+			},
+		},
+	})
+}
+
+func TestResourceJob_FakeAPI_RemoveBlocks(t *testing.T) {
+	server := fakeapi.NewServer(fakeapi.Options{})
+	defer server.Close()
+
+	config := func(blocks string) string {
+		return testFakeAPIProviderConfig(server) + fmt.Sprintf(`
+resource "cronjoborg_job" "test" {
+  title = "Report"
+  url   = "https://example.com/report"
+%s
+}
+`, blocks)
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { testFakeAPIPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testCheckFakeAPIJobsDestroyed(server),
+		Steps: []resource.TestStep{
+			{
+				Config: config(`
+  auth {
+    enable   = true
+    user     = "report"
+    password = "secret"
+  }
+
+  notification {
+    on_failure = true
+  }
+
+  extended_data {
+    headers = {
+      "X-Token" = "secret"
+    }
+  }
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("cronjoborg_job.test", "auth.#", "1"),
+					resource.TestCheckResourceAttr("cronjoborg_job.test", "notification.#", "1"),
+					resource.TestCheckResourceAttr("cronjoborg_job.test", "extended_data.#", "1"),
+				),
+			},
+			{
+				// Removing the blocks resets the settings instead of leaving them unchanged.
+				Config: config(""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("cronjoborg_job.test", "auth.#", "0"),
+					resource.TestCheckResourceAttr("cronjoborg_job.test", "notification.#", "0"),
+					resource.TestCheckResourceAttr("cronjoborg_job.test", "extended_data.#", "0"),
+					testCheckFakeAPIJob(server, 1, func(job client.DetailedJob) error {
+						if job.Auth != (client.JobAuth{}) {
+							return fmt.Errorf("expected auth to be reset, got %+v", job.Auth)
+						}
+						if job.Notification != (client.JobNotificationSettings{}) {
+							return fmt.Errorf("expected notifications to be reset, got %+v", job.Notification)
+						}
+						if len(job.ExtendedData.Headers) != 0 {
+							return fmt.Errorf("expected headers to be removed, got %v", job.ExtendedData.Headers)
+						}
+						return nil
+					}),
+				),
+			},
+		},
+	})
+}
+
+func TestResourceJob_FakeAPI_RemoveSchedule(t *testing.T) {
+	server := fakeapi.NewServer(fakeapi.Options{})
+	defer server.Close()
+
+	config := func(schedule string) string {
+		return testFakeAPIProviderConfig(server) + fmt.Sprintf(`
+resource "cronjoborg_job" "test" {
+  title = "Report"
+  url   = "https://example.com/report"
+
+  notification {}
+%s
+}
+`, schedule)
+	}
+	scheduled := config(`
+  schedule {
+    hours   = [9]
+    minutes = [0]
+  }
+`)
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { testFakeAPIPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testCheckFakeAPIJobsDestroyed(server),
+		Steps: []resource.TestStep{
+			{
+				Config: scheduled,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("cronjoborg_job.test", "schedule.0.hours.0", "9"),
+					resource.TestCheckResourceAttr("cronjoborg_job.test", "notification.#", "1"),
+				),
+			},
+			{
+				// A configured notification block with all notifications off plans no changes.
+				Config:   scheduled,
+				PlanOnly: true,
+			},
+			{
+				// Removing the schedule block resets the job to the default schedule.
+				Config: config(""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("cronjoborg_job.test", "schedule.#", "0"),
+					testCheckFakeAPIJob(server, 1, func(job client.DetailedJob) error {
+						if !job.Schedule.Equal(defaultJobSchedule()) {
+							return fmt.Errorf("expected the default schedule, got %+v", job.Schedule)
+						}
+						return nil
+					}),
+				),
+			},
+			{
+				Config:   config(""),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func TestResourceJob_FakeAPI_OnConflict(t *testing.T) {
+	server := fakeapi.NewServer(fakeapi.Options{})
+	defer server.Close()
+
+	// A job created in the console before it was added to the configuration.
+	server.API.AddJob(client.DetailedJob{
+		Job: client.Job{
+			Title:    "Heartbeat",
+			URL:      "https://example.com/heartbeat",
+			Schedule: client.JobSchedule{Timezone: "UTC", Hours: []int{4}, MDays: []int{-1}, Minutes: []int{0}, Months: []int{-1}, WDays: []int{-1}},
+		},
+		ExtendedData: client.JobExtendedData{Body: "from the console"},
+	})
+
+	config := func(onConflict string) string {
+		return testFakeAPIProviderConfig(server) + fmt.Sprintf(`
+resource "cronjoborg_job" "test" {
+  title       = "Heartbeat"
+  url         = "https://example.com/heartbeat"
+  enabled     = true
+  on_conflict = %q
+}
+`, onConflict)
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { testFakeAPIPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testCheckFakeAPIJobsDestroyed(server),
+		Steps: []resource.TestStep{
+			{
+				Config:      config("error"),
+				ExpectError: regexp.MustCompile(`conflicts with job 1 \("Heartbeat", https://example.com/heartbeat\)`),
+			},
+			{
+				Config: config("adopt"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("cronjoborg_job.test", "id", "1"),
+					resource.TestCheckResourceAttr("cronjoborg_job.test", "on_conflict", "adopt"),
+					testCheckFakeAPIJob(server, 1, func(job client.DetailedJob) error {
+						if !job.Enabled || job.ExtendedData.Body != "" || len(job.Schedule.Hours) != 1 || job.Schedule.Hours[0] != -1 {
+							return fmt.Errorf("expected the adopted job to be overwritten with the configuration, got %+v", job)
+						}
+						if n := len(server.API.Jobs()); n != 1 {
+							return fmt.Errorf("expected no duplicate job, got %d jobs", n)
+						}
+						return nil
+					}),
+				),
+			},
+			{
+				// Changing on_conflict later has no effect on the job.
+				Config: config("error"),
+				Check:  resource.TestCheckResourceAttr("cronjoborg_job.test", "on_conflict", "error"),
+			},
+		},
+	})
+}
+
+func TestResourceJob_FakeAPI_CronExpression(t *testing.T) {
+	server := fakeapi.NewServer(fakeapi.Options{})
+	defer server.Close()
+
+	config := func(schedule string) string {
+		return testFakeAPIProviderConfig(server) + fmt.Sprintf(`
+resource "cronjoborg_job" "test" {
+  title = "Business hours"
+  url   = "https://example.com/ping"
+
+  schedule {
+%s
+  }
+}
+`, schedule)
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { testFakeAPIPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testCheckFakeAPIJobsDestroyed(server),
+		Steps: []resource.TestStep{
+			{
+				Config:      config(`cron_expression = "0 9 * * *"` + "\n" + `hours = [9]`),
+				ExpectError: regexp.MustCompile(`conflicts with schedule.0.hours`),
+			},
+			{
+				Config:      config(`cron_expression = "0 25 * * *"`),
+				ExpectError: regexp.MustCompile(`value 25 out of range in hour field`),
+			},
+			{
+				Config: config(`cron_expression = "*/15 9-17 * * MON-FRI"`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("cronjoborg_job.test", "schedule.0.cron_expression", "*/15 9-17 * * MON-FRI"),
+					resource.TestCheckResourceAttr("cronjoborg_job.test", "schedule.0.hours.#", "9"),
+					testCheckFakeAPIJob(server, 1, func(job client.DetailedJob) error {
+						if got := job.Schedule.CronExpression(); got != "0,15,30,45 9-17 * * 1-5" {
+							return fmt.Errorf("unexpected schedule %s", got)
+						}
+						return nil
+					}),
+				),
+			},
+			{
+				// An equivalent expression does not produce a diff.
+				Config:   config(`cron_expression = "0,15,30,45 9-17 * * 1-5"`),
+				PlanOnly: true,
+			},
+			{
+				// A schedule changed in the console is reverted.
+				PreConfig: func() {
+					server.API.UpdateJob(1, func(job *client.DetailedJob) { job.Schedule.Hours = []int{3} })
+				},
+				Config: config(`cron_expression = "*/15 9-17 * * MON-FRI"`),
+				Check: testCheckFakeAPIJob(server, 1, func(job client.DetailedJob) error {
+					if len(job.Schedule.Hours) != 9 {
+						return fmt.Errorf("expected the hours to be reverted, got %v", job.Schedule.Hours)
+					}
+					return nil
+				}),
+			},
+			{
+				// Switching back to lists resets the fields the lists omit.
+				Config: config(`hours = [4]` + "\n" + `minutes = [30]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("cronjoborg_job.test", "schedule.0.cron_expression", ""),
+					testCheckFakeAPIJob(server, 1, func(job client.DetailedJob) error {
+						if got := job.Schedule.CronExpression(); got != "30 4 * * *" {
+							return fmt.Errorf("unexpected schedule %s", got)
+						}
+						return nil
+					}),
+				),
+			},
+		},
+	})
+}
+
+func TestResourceJob_FakeAPI_Expiry(t *testing.T) {
+	server := fakeapi.NewServer(fakeapi.Options{})
+	defer server.Close()
+
+	config := func(schedule string) string {
+		return testFakeAPIProviderConfig(server) + fmt.Sprintf(`
+resource "cronjoborg_job" "test" {
+  title = "Campaign"
+  url   = "https://example.com/campaign"
+
+  schedule {
+%s
+  }
+}
+`, schedule)
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { testFakeAPIPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testCheckFakeAPIJobsDestroyed(server),
+		Steps: []resource.TestStep{
+			{
+				Config:      config(`timezone = "Europe/Berln"`),
+				ExpectError: regexp.MustCompile(`invalid schedule timezone "Europe/Berln"`),
+			},
+			{
+				Config:      config(`expires_at = 20251399000000`),
+				ExpectError: regexp.MustCompile(`invalid schedule expiresAt 20251399000000`),
+			},
+			{
+				Config: config(`timezone = "Europe/Berlin"` + "\n" + `expires_at_rfc3339 = "2030-06-30T22:00:00Z"`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("cronjoborg_job.test", "schedule.0.expires_at", "20300701000000"),
+					resource.TestCheckResourceAttr("cronjoborg_job.test", "schedule.0.expires_at_rfc3339", "2030-06-30T22:00:00Z"),
+					resource.TestCheckResourceAttr("cronjoborg_job.test", "schedule.0.expires_at_human", "2030-07-01 00:00:00 CEST"),
+					testCheckFakeAPIJob(server, 1, func(job client.DetailedJob) error {
+						if job.Schedule.ExpiresAt != 20300701000000 {
+							return fmt.Errorf("expected the expiry in the schedule's time zone, got %d", job.Schedule.ExpiresAt)
+						}
+						return nil
+					}),
+				),
+			},
+			{
+				// The same instant in another offset does not produce a diff.
+				Config:   config(`timezone = "Europe/Berlin"` + "\n" + `expires_at_rfc3339 = "2030-07-01T00:00:00+02:00"`),
+				PlanOnly: true,
+			},
+			{
+				// The expiry follows the time zone.
+				Config: config(`timezone = "UTC"` + "\n" + `expires_at_rfc3339 = "2030-06-30T22:00:00Z"`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("cronjoborg_job.test", "schedule.0.expires_at", "20300630220000"),
+					resource.TestCheckResourceAttr("cronjoborg_job.test", "schedule.0.expires_at_human", "2030-06-30 22:00:00 UTC"),
+				),
+			},
+			{
+				Config: config(`timezone = "UTC"`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("cronjoborg_job.test", "schedule.0.expires_at", "0"),
+					resource.TestCheckResourceAttr("cronjoborg_job.test", "schedule.0.expires_at_human", "never"),
+				),
+			},
+		},
+	})
+}
+
+func TestResourceJob_FakeAPI_ScheduleListOrder(t *testing.T) {
+	server := fakeapi.NewServer(fakeapi.Options{})
+	defer server.Close()
+
+	config := func(hours string) string {
+		return testFakeAPIProviderConfig(server) + fmt.Sprintf(`
+resource "cronjoborg_job" "test" {
+  title = "Twice a day"
+  url   = "https://example.com/twice"
+
+  schedule {
+    hours   = %s
+    minutes = [0]
+  }
+}
+`, hours)
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { testFakeAPIPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testCheckFakeAPIJobsDestroyed(server),
+		Steps: []resource.TestStep{
+			{
+				Config: config(`[17, 9, 17]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("cronjoborg_job.test", "schedule.0.hours.#", "2"),
+					resource.TestCheckResourceAttr("cronjoborg_job.test", "schedule.0.hours.0", "9"),
+					testCheckFakeAPIJob(server, 1, func(job client.DetailedJob) error {
+						if !slices.Equal(job.Schedule.Hours, []int{9, 17}) {
+							return fmt.Errorf("expected sorted and deduplicated hours, got %v", job.Schedule.Hours)
+						}
+						return nil
+					}),
+				),
+			},
+			{
+				Config:   config(`[9, 17]`),
+				PlanOnly: true,
+			},
+			{
+				// The order returned by the API does not matter either.
+				PreConfig: func() {
+					server.API.UpdateJob(1, func(job *client.DetailedJob) { job.Schedule.Hours = []int{17, 9} })
+				},
+				Config:   config(`[17, 9]`),
+				PlanOnly: true,
+			},
+			{
+				Config:             config(`[9, 12, 17]`),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				// Removing the list resets it to every hour.
+				Config:             config(`null`),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func TestResourceJob_FakeAPI_ScheduleLint(t *testing.T) {
+	server := fakeapi.NewServer(fakeapi.Options{})
+	defer server.Close()
+
+	config := func(schedule string) string {
+		return testFakeAPIProviderConfig(server) + fmt.Sprintf(`
+resource "cronjoborg_job" "test" {
+  title = "Month end"
+  url   = "https://example.com/month-end"
+
+  schedule {
+%s
+  }
+}
+`, schedule)
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { testFakeAPIPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testCheckFakeAPIJobsDestroyed(server),
+		Steps: []resource.TestStep{
+			{
+				Config:      config(`cron_expression = "0 0 31 2,4,6 *"`),
+				ExpectError: regexp.MustCompile(`Schedule never runs`),
+			},
+			{
+				Config:      config(`hours = [-1, 5]`),
+				ExpectError: regexp.MustCompile(`schedule.hours \[-1 5\] combines -1`),
+			},
+			{
+				// Warnings do not block the apply.
+				Config: config(`timezone = "Europe/Berlin"` + "\n" + `hours = [2, 1]` + "\n" + `minutes = [30]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("cronjoborg_job.test", "schedule.0.hours.#", "2"),
+				),
+			},
+		},
+	})
+}
+
+// testCheckFakeAPIJob runs check against the job with the given ID stored in the fake API.
+func testCheckFakeAPIJob(server *fakeapi.Server, jobID int, check func(job client.DetailedJob) error) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		job, ok := server.API.Job(jobID)
+		if !ok {
+			return fmt.Errorf("job %d does not exist in the fake API", jobID)
+		}
+		return check(job)
+	}
+}
+
+// testCheckFakeAPIJobsDestroyed verifies that no jobs are left in the fake API.
+func testCheckFakeAPIJobsDestroyed(server *fakeapi.Server) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if jobs := server.API.Jobs(); len(jobs) != 0 {
+			return fmt.Errorf("expected all jobs to be destroyed, %d left", len(jobs))
+		}
+		return nil
+	}
+}
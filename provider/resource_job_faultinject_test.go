@@ -0,0 +1,168 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/plain-insure/terraform-provider-cronjoborg/internal/fakeapi"
+	"github.com/plain-insure/terraform-provider-cronjoborg/internal/faultinject"
+)
+
+// newFaultyFakeAPIServer starts the fake API behind a fault injector.
+func newFaultyFakeAPIServer(rules ...faultinject.Rule) (*fakeapi.Server, *faultinject.Injector) {
+	api := fakeapi.NewAPI(fakeapi.Options{})
+	injector := faultinject.New(api, rules...)
+	return &fakeapi.Server{Server: httptest.NewServer(injector), API: api}, injector
+}
+
+func TestResourceJob_FaultInjection_TransientFailures(t *testing.T) {
+	server, injector := newFaultyFakeAPIServer(
+		faultinject.Rule{
+			Method: http.MethodPut,
+			Path:   "/jobs",
+			Times:  1,
+			Fault:  faultinject.Fault{StatusCode: http.StatusTooManyRequests, RetryAfter: time.Second},
+		},
+		faultinject.Rule{
+			Method: http.MethodGet,
+			Path:   "/jobs/*",
+			Times:  1,
+			Fault:  faultinject.Fault{StatusCode: http.StatusBadGateway},
+		},
+		faultinject.Rule{
+			Method: http.MethodGet,
+			Path:   "/jobs/*",
+			Times:  1,
+			Fault:  faultinject.Fault{TruncateBody: 32, Latency: 100 * time.Millisecond},
+		},
+		faultinject.Rule{
+			Method: http.MethodPatch,
+			Path:   "/jobs/*",
+			Times:  1,
+			Fault:  faultinject.Fault{DropConnection: true},
+		},
+	)
+	defer server.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { testFakeAPIPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testCheckFakeAPIJobsDestroyed(server),
+		Steps: []resource.TestStep{
+			{
+				Config: testFakeAPIProviderConfig(server) + `
+resource "cronjoborg_job" "test" {
+  title = "Flaky"
+  url   = "https://example.com/flaky"
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("cronjoborg_job.test", "id", "1"),
+					resource.TestCheckResourceAttr("cronjoborg_job.test", "title", "Flaky"),
+				),
+			},
+			{
+				Config: testFakeAPIProviderConfig(server) + `
+resource "cronjoborg_job" "test" {
+  title = "Flaky, renamed"
+  url   = "https://example.com/flaky"
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("cronjoborg_job.test", "id", "1"),
+					resource.TestCheckResourceAttr("cronjoborg_job.test", "title", "Flaky, renamed"),
+					func(*terraform.State) error {
+						if n := len(injector.Injected()); n != 4 {
+							return fmt.Errorf("expected 4 injected faults, got %d: %v", n, injector.Injected())
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestResourceJob_FaultInjection_EmptyHeadersAsArray(t *testing.T) {
+	server, _ := newFaultyFakeAPIServer(faultinject.Rule{
+		Method: http.MethodGet,
+		Fault:  faultinject.Fault{Transform: faultinject.EmptyObjectsAsArrays()},
+	})
+	defer server.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { testFakeAPIPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testCheckFakeAPIJobsDestroyed(server),
+		Steps: []resource.TestStep{
+			{
+				Config: testFakeAPIProviderConfig(server) + `
+resource "cronjoborg_job" "test" {
+  title = "Quirky"
+  url   = "https://example.com/quirky"
+
+  extended_data {
+    body = "ping"
+  }
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("cronjoborg_job.test", "extended_data.0.body", "ping"),
+					resource.TestCheckResourceAttr("cronjoborg_job.test", "extended_data.0.headers.%", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestResourceJob_FaultInjection_LostCreateResponse(t *testing.T) {
+	withFastOrphanLookup(t)
+
+	server, _ := newFaultyFakeAPIServer(faultinject.Rule{
+		Method: http.MethodPut,
+		Path:   "/jobs",
+		Times:  1,
+		Fault:  faultinject.Fault{DropAfterUpstream: true},
+	})
+	defer server.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { testFakeAPIPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testCheckFakeAPIJobsDestroyed(server),
+		Steps: []resource.TestStep{
+			{
+				Config: testFakeAPIProviderConfig(server) + `
+resource "cronjoborg_job" "test" {
+  title = "Orphan"
+  url   = "https://example.com/orphan"
+
+  recover_failed_creates = true
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("cronjoborg_job.test", "id", "1"),
+					resource.TestCheckResourceAttr("cronjoborg_job.test", "extended_data.0.headers.%", "0"),
+					func(*terraform.State) error {
+						jobs := server.API.Jobs()
+						if len(jobs) != 1 {
+							return fmt.Errorf("expected the job to be adopted instead of duplicated, got %d jobs", len(jobs))
+						}
+						if len(jobs[0].ExtendedData.Headers) != 0 {
+							return fmt.Errorf("expected the idempotency key to be removed, got headers %v", jobs[0].ExtendedData.Headers)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,84 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/plain-insure/terraform-provider-cronjoborg/client"
+)
+
+// resourceJobStateUpgradeV0 sorts and deduplicates the schedule lists of version 0
+// state, which stored them in configuration order, and stores [-1] as an empty list
+// like resourceJobRead does. It also sets the defaults of the provider-side settings
+// that version 0 state written before they existed lacks, as resourceJobImport does.
+func resourceJobStateUpgradeV0(ctx context.Context, rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
+	if rawState == nil {
+		return rawState, nil
+	}
+
+	for key, value := range map[string]interface{}{
+		"on_conflict":            onConflictCreate,
+		"conflict_match":         conflictMatchTitleAndURL,
+		"recover_failed_creates": false,
+	} {
+		if rawState[key] == nil {
+			rawState[key] = value
+		}
+	}
+
+	schedules, ok := rawState["schedule"].([]interface{})
+	if !ok {
+		return rawState, nil
+	}
+	for _, raw := range schedules {
+		schedule, ok := raw.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("schedule element must be a map, got %T", raw)
+		}
+		for _, field := range []string{"hours", "mdays", "minutes", "months", "wdays"} {
+			values, err := upgradeScheduleListV0(schedule[field])
+			if err != nil {
+				return nil, fmt.Errorf("schedule.%s: %w", field, err)
+			}
+			if values != nil {
+				schedule[field] = values
+			}
+		}
+	}
+
+	return rawState, nil
+}
+
+// upgradeScheduleListV0 normalizes a schedule list decoded from JSON state. It returns
+// nil if the list is not set.
+func upgradeScheduleListV0(raw interface{}) ([]interface{}, error) {
+	if raw == nil {
+		return nil, nil
+	}
+	list, ok := raw.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("must be a list, got %T", raw)
+	}
+
+	values := make([]int, len(list))
+	for i, v := range list {
+		// JSON numbers are decoded as float64.
+		switch number := v.(type) {
+		case float64:
+			values[i] = int(number)
+		case int:
+			values[i] = number
+		default:
+			return nil, fmt.Errorf("values must be numbers, got %T", v)
+		}
+	}
+
+	upgraded := []interface{}{}
+	for _, value := range normalizeScheduleSlice(client.NormalizeScheduleList(values)) {
+		upgraded = append(upgraded, value)
+	}
+	return upgraded, nil
+}
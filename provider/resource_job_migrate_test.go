@@ -0,0 +1,129 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	ctyjson "github.com/hashicorp/go-cty/cty/json"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestResourceJobStateUpgradeV0(t *testing.T) {
+	rawState := map[string]interface{}{
+		"id":    "42",
+		"title": "Backup",
+		"schedule": []interface{}{
+			map[string]interface{}{
+				"timezone":   "UTC",
+				"expires_at": float64(0),
+				"hours":      []interface{}{float64(17), float64(9), float64(17)},
+				"mdays":      []interface{}{float64(-1)},
+				"minutes":    []interface{}{float64(30), float64(0)},
+				"months":     []interface{}{},
+			},
+		},
+	}
+
+	got, err := resourceJobStateUpgradeV0(context.Background(), rawState, nil)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	want := map[string]interface{}{
+		"timezone":   "UTC",
+		"expires_at": float64(0),
+		"hours":      []interface{}{9, 17},
+		"mdays":      []interface{}{},
+		"minutes":    []interface{}{0, 30},
+		"months":     []interface{}{},
+	}
+	schedules, ok := got["schedule"].([]interface{})
+	if !ok || len(schedules) != 1 || !reflect.DeepEqual(schedules[0], want) {
+		t.Errorf("Expected schedule %v, got %v", want, got["schedule"])
+	}
+	if got["title"] != "Backup" {
+		t.Errorf("Expected other attributes to be kept, got %v", got)
+	}
+}
+
+func TestResourceJobStateUpgradeV0_NoSchedule(t *testing.T) {
+	rawState := map[string]interface{}{"id": "42"}
+
+	got, err := resourceJobStateUpgradeV0(context.Background(), rawState, nil)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	want := map[string]interface{}{
+		"id":                     "42",
+		"on_conflict":            onConflictCreate,
+		"conflict_match":         conflictMatchTitleAndURL,
+		"recover_failed_creates": false,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected state without schedule to only get the defaults, got %v", got)
+	}
+}
+
+func TestResourceJobStateUpgradeV0_InvalidList(t *testing.T) {
+	rawState := map[string]interface{}{
+		"schedule": []interface{}{
+			map[string]interface{}{"hours": []interface{}{"nine"}},
+		},
+	}
+
+	if _, err := resourceJobStateUpgradeV0(context.Background(), rawState, nil); err == nil {
+		t.Error("Expected an error for non-numeric schedule values")
+	}
+}
+
+// TestResourceJobStateUpgradeV0_EmptyPlan checks that state written before on_conflict,
+// conflict_match and recover_failed_creates existed plans no changes after the upgrade.
+func TestResourceJobStateUpgradeV0_EmptyPlan(t *testing.T) {
+	r := resourceJob()
+	ty := r.CoreConfigSchema().ImpliedType()
+
+	current, err := testMockJobState(t).AttrsAsObjectValue(ty)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := ctyjson.Marshal(current, ty)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var rawState map[string]interface{}
+	if err := json.Unmarshal(data, &rawState); err != nil {
+		t.Fatal(err)
+	}
+	delete(rawState, "on_conflict")
+	delete(rawState, "conflict_match")
+	delete(rawState, "recover_failed_creates")
+
+	upgraded, err := resourceJobStateUpgradeV0(context.Background(), rawState, nil)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if data, err = json.Marshal(upgraded); err != nil {
+		t.Fatal(err)
+	}
+	value, err := ctyjson.Unmarshal(data, ty)
+	if err != nil {
+		t.Fatal(err)
+	}
+	state, err := r.ShimInstanceStateFromValue(value)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	diff, err := r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(testMockJobConfig), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !diff.Empty() {
+		t.Errorf("Expected an empty plan after the upgrade, got %v", diff.Attributes)
+	}
+}
@@ -0,0 +1,290 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/plain-insure/terraform-provider-cronjoborg/client"
+	"github.com/plain-insure/terraform-provider-cronjoborg/client/clienttest"
+)
+
+var testMockJobConfig = map[string]interface{}{
+	"title": "Backup",
+	"url":   "https://example.com/backup",
+}
+
+// testMockJob returns the job the API stores for testMockJobConfig.
+func testMockJob() *client.DetailedJob {
+	return &client.DetailedJob{
+		Job: client.Job{
+			JobID:          1,
+			Title:          "Backup",
+			URL:            "https://example.com/backup",
+			RequestTimeout: -1,
+			Schedule: client.JobSchedule{
+				Timezone: "UTC",
+				Hours:    []int{-1},
+				MDays:    []int{-1},
+				Minutes:  []int{-1},
+				Months:   []int{-1},
+				WDays:    []int{-1},
+			},
+		},
+	}
+}
+
+// testMockJobState returns the state of a job that has been created from
+// testMockJobConfig and read back.
+func testMockJobState(t *testing.T) *terraform.InstanceState {
+	t.Helper()
+
+	api := &clienttest.MockJobsAPI{
+		GetJobDetailsFunc: func(ctx context.Context, jobID string) (*client.DetailedJob, error) {
+			return testMockJob(), nil
+		},
+	}
+	d := schema.TestResourceDataRaw(t, resourceJob().Schema, testMockJobConfig)
+	d.SetId("1")
+	if diags := resourceJobRead(context.Background(), d, api); diags.HasError() {
+		t.Fatalf("Read failed: %v", diags)
+	}
+	return d.State()
+}
+
+// testMockJobUpdateData returns the resource data of an update of state to config.
+func testMockJobUpdateData(t *testing.T, state *terraform.InstanceState, config map[string]interface{}) *schema.ResourceData {
+	t.Helper()
+
+	r := resourceJob()
+	diff, err := r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(config), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	d, err := schema.InternalMap(r.Schema).Data(state, diff)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return d
+}
+
+func TestResourceJobRead_Normalization(t *testing.T) {
+	tests := []struct {
+		name     string
+		modify   func(job *client.DetailedJob)
+		expected map[string]string
+	}{
+		{
+			name: "default blocks are cleared",
+			modify: func(job *client.DetailedJob) {
+				job.Auth = client.JobAuth{User: "  ", Password: "\n"}
+				job.ExtendedData = client.JobExtendedData{Headers: map[string]string{}, Body: "  "}
+			},
+			expected: map[string]string{
+				"auth.#":          "0",
+				"notification.#":  "0",
+				"extended_data.#": "0",
+			},
+		},
+		{
+			name: "whitespace is trimmed",
+			modify: func(job *client.DetailedJob) {
+				job.Auth = client.JobAuth{Enable: true, User: " admin ", Password: "secret\n"}
+				job.ExtendedData = client.JobExtendedData{Headers: map[string]string{"X-Token": "abc"}, Body: " {} \n"}
+			},
+			expected: map[string]string{
+				"auth.#":                          "1",
+				"auth.0.user":                     "admin",
+				"auth.0.password":                 "secret",
+				"extended_data.#":                 "1",
+				"extended_data.0.body":            "{}",
+				"extended_data.0.headers.X-Token": "abc",
+			},
+		},
+		{
+			name:   "schedule sentinels become empty lists",
+			modify: func(job *client.DetailedJob) { job.Schedule.Minutes = []int{0, 30} },
+			expected: map[string]string{
+				"schedule.0.hours.#":    "0",
+				"schedule.0.minutes.#":  "2",
+				"schedule.0.minutes.1":  "30",
+				"schedule.0.timezone":   "UTC",
+				"schedule.0.expires_at": "0",
+				"schedule.0.wdays.#":    "0",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			job := testMockJob()
+			tt.modify(job)
+			api := &clienttest.MockJobsAPI{
+				GetJobDetailsFunc: func(ctx context.Context, jobID string) (*client.DetailedJob, error) {
+					return job, nil
+				},
+			}
+
+			d := resourceJob().Data(nil)
+			d.SetId("1")
+			if diags := resourceJobRead(context.Background(), d, api); diags.HasError() {
+				t.Fatalf("Read failed: %v", diags)
+			}
+
+			attributes := d.State().Attributes
+			for key, want := range tt.expected {
+				if got := attributes[key]; got != want {
+					t.Errorf("Expected %s = %q, got %q", key, want, got)
+				}
+			}
+		})
+	}
+}
+
+func TestResourceJobRead_ListedJob(t *testing.T) {
+	api := &clienttest.MockJobsAPI{
+		ListedJobFunc: func(ctx context.Context, jobID string) (*client.Job, bool, error) {
+			job := testMockJob().Job
+			job.Title = "Listed"
+			return &job, true, nil
+		},
+	}
+
+	d := resourceJob().Data(testMockJobState(t))
+	if diags := resourceJobRead(context.Background(), d, api); diags.HasError() {
+		t.Fatalf("Read failed: %v", diags)
+	}
+	if title := d.Get("title"); title != "Listed" {
+		t.Errorf("Expected the title from the job list, got %q", title)
+	}
+	if calls := api.Calls(); !reflect.DeepEqual(calls, []string{"ListedJob 1"}) {
+		t.Errorf("Expected only the job list to be read, got %v", calls)
+	}
+}
+
+func TestResourceJobUpdate_PartialPatch(t *testing.T) {
+	config := map[string]interface{}{
+		"title": "Nightly backup",
+		"url":   "https://example.com/backup",
+	}
+
+	tests := []struct {
+		name        string
+		bulkRefresh bool
+		expected    func(title string) client.JobPatch
+	}{
+		{
+			name: "only changed fields",
+			expected: func(title string) client.JobPatch {
+				return client.JobPatch{Title: &title}
+			},
+		},
+		{
+			name:        "detail blocks with bulk refresh",
+			bulkRefresh: true,
+			expected: func(title string) client.JobPatch {
+				return client.JobPatch{
+					Title:        &title,
+					Auth:         &client.JobAuth{},
+					Notification: &client.JobNotificationSettings{},
+					ExtendedData: &client.JobExtendedData{Headers: map[string]string{}},
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var patches []client.JobPatch
+			api := &clienttest.MockJobsAPI{
+				JobCacheEnabledFunc: func() bool { return tt.bulkRefresh },
+				UpdateJobFunc: func(ctx context.Context, jobID string, patch client.JobPatch) error {
+					patches = append(patches, patch)
+					return nil
+				},
+				GetJobDetailsFunc: func(ctx context.Context, jobID string) (*client.DetailedJob, error) {
+					job := testMockJob()
+					job.Title = "Nightly backup"
+					return job, nil
+				},
+			}
+
+			d := testMockJobUpdateData(t, testMockJobState(t), config)
+			if diags := resourceJobUpdate(context.Background(), d, api); diags.HasError() {
+				t.Fatalf("Update failed: %v", diags)
+			}
+
+			if len(patches) != 1 {
+				t.Fatalf("Expected 1 update, got %d", len(patches))
+			}
+			// Compare the request payloads, in which nil and empty header maps are equivalent.
+			got, err := json.Marshal(patches[0])
+			if err != nil {
+				t.Fatal(err)
+			}
+			want, err := json.Marshal(tt.expected("Nightly backup"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != string(want) {
+				t.Errorf("Expected patch %s, got %s", want, got)
+			}
+		})
+	}
+}
+
+func TestExpandJobPatch_DiffsPriorState(t *testing.T) {
+	api := &clienttest.MockJobsAPI{
+		GetJobDetailsFunc: func(ctx context.Context, jobID string) (*client.DetailedJob, error) {
+			job := testMockJob()
+			job.Schedule.Hours = []int{9, 17}
+			job.Auth = client.JobAuth{Enable: true, User: "backup", Password: "secret"}
+			return job, nil
+		},
+	}
+	state := schema.TestResourceDataRaw(t, resourceJob().Schema, testMockJobConfig)
+	state.SetId("1")
+	if diags := resourceJobRead(context.Background(), state, api); diags.HasError() {
+		t.Fatalf("Read failed: %v", diags)
+	}
+
+	// The reordered hours are the same set, and the removed auth block is reset.
+	d := testMockJobUpdateData(t, state.State(), map[string]interface{}{
+		"title": "Nightly backup",
+		"url":   "https://example.com/backup",
+		"schedule": []interface{}{map[string]interface{}{
+			"hours": []interface{}{17, 9},
+		}},
+	})
+	patch, err := expandJobPatch(d)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	title := "Nightly backup"
+	if want := (client.JobPatch{Title: &title, Auth: &client.JobAuth{}}); !reflect.DeepEqual(patch, want) {
+		t.Errorf("Expected patch %+v, got %+v", want, patch)
+	}
+}
+
+func TestResourceJobUpdate_NoChanges(t *testing.T) {
+	api := &clienttest.MockJobsAPI{
+		GetJobDetailsFunc: func(ctx context.Context, jobID string) (*client.DetailedJob, error) {
+			return testMockJob(), nil
+		},
+	}
+
+	d := testMockJobUpdateData(t, testMockJobState(t), testMockJobConfig)
+	if diags := resourceJobUpdate(context.Background(), d, api); diags.HasError() {
+		t.Fatalf("Update failed: %v", diags)
+	}
+	if calls := api.Calls(); !reflect.DeepEqual(calls, []string{"ListedJob 1", "GetJobDetails 1"}) {
+		t.Errorf("Expected no update request, got %v", calls)
+	}
+}
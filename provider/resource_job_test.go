@@ -4,9 +4,12 @@
 package provider
 
 import (
+	"context"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/plain-insure/terraform-provider-cronjoborg/client"
 )
 
 func TestResourceJob_Schema(t *testing.T) {
@@ -17,17 +20,17 @@ func TestResourceJob_Schema(t *testing.T) {
 	}
 
 	// Test that CRUD functions are defined
-	if resource.Create == nil {
-		t.Error("Create function should be defined")
+	if resource.CreateContext == nil {
+		t.Error("CreateContext function should be defined")
 	}
-	if resource.Read == nil {
-		t.Error("Read function should be defined")
+	if resource.ReadContext == nil {
+		t.Error("ReadContext function should be defined")
 	}
-	if resource.Update == nil {
-		t.Error("Update function should be defined")
+	if resource.UpdateContext == nil {
+		t.Error("UpdateContext function should be defined")
 	}
-	if resource.Delete == nil {
-		t.Error("Delete function should be defined")
+	if resource.DeleteContext == nil {
+		t.Error("DeleteContext function should be defined")
 	}
 
 	// Test schema fields
@@ -79,19 +82,14 @@ func TestBuildSchedule_NoScheduleBlock(t *testing.T) {
 	}
 
 	// Check default values
-	if schedule["timezone"] != "UTC" {
-		t.Errorf("Expected timezone to be UTC, got %v", schedule["timezone"])
+	if schedule.Timezone != "UTC" {
+		t.Errorf("Expected timezone to be UTC, got %v", schedule.Timezone)
 	}
-	if schedule["expiresAt"] != 0 {
-		t.Errorf("Expected expiresAt to be 0, got %v", schedule["expiresAt"])
+	if schedule.ExpiresAt != 0 {
+		t.Errorf("Expected expiresAt to be 0, got %v", schedule.ExpiresAt)
 	}
 
-	checkScheduleField := func(fieldName string, expected []int) {
-		actual, ok := schedule[fieldName].([]int)
-		if !ok {
-			t.Errorf("Expected %s to be []int, got %T", fieldName, schedule[fieldName])
-			return
-		}
+	checkScheduleField := func(fieldName string, actual, expected []int) {
 		if len(actual) != len(expected) {
 			t.Errorf("Expected %s to have length %d, got %d", fieldName, len(expected), len(actual))
 			return
@@ -103,11 +101,11 @@ func TestBuildSchedule_NoScheduleBlock(t *testing.T) {
 		}
 	}
 
-	checkScheduleField("hours", []int{-1})
-	checkScheduleField("mdays", []int{-1})
-	checkScheduleField("minutes", []int{-1})
-	checkScheduleField("months", []int{-1})
-	checkScheduleField("wdays", []int{-1})
+	checkScheduleField("hours", schedule.Hours, []int{-1})
+	checkScheduleField("mdays", schedule.MDays, []int{-1})
+	checkScheduleField("minutes", schedule.Minutes, []int{-1})
+	checkScheduleField("months", schedule.Months, []int{-1})
+	checkScheduleField("wdays", schedule.WDays, []int{-1})
 }
 
 func TestBuildSchedule_PartialScheduleBlock(t *testing.T) {
@@ -140,28 +138,20 @@ func TestBuildSchedule_PartialScheduleBlock(t *testing.T) {
 	}
 
 	// Check that explicitly set values are preserved
-	if result["timezone"] != "America/New_York" {
-		t.Errorf("Expected timezone to be America/New_York, got %v", result["timezone"])
+	if result.Timezone != "America/New_York" {
+		t.Errorf("Expected timezone to be America/New_York, got %v", result.Timezone)
 	}
-	if result["expiresAt"] != 20241231235959 {
-		t.Errorf("Expected expiresAt to be 20241231235959, got %v", result["expiresAt"])
+	if result.ExpiresAt != 20241231235959 {
+		t.Errorf("Expected expiresAt to be 20241231235959, got %v", result.ExpiresAt)
 	}
 
 	// Check that hours are preserved
-	hours, ok := result["hours"].([]int)
-	if !ok {
-		t.Errorf("Expected hours to be []int, got %T", result["hours"])
-	} else if len(hours) != 2 || hours[0] != 9 || hours[1] != 17 {
+	if hours := result.Hours; len(hours) != 2 || hours[0] != 9 || hours[1] != 17 {
 		t.Errorf("Expected hours to be [9, 17], got %v", hours)
 	}
 
 	// Check that other fields default to [-1]
-	checkScheduleField := func(fieldName string, expected []int) {
-		actual, ok := result[fieldName].([]int)
-		if !ok {
-			t.Errorf("Expected %s to be []int, got %T", fieldName, result[fieldName])
-			return
-		}
+	checkScheduleField := func(fieldName string, actual, expected []int) {
 		if len(actual) != len(expected) {
 			t.Errorf("Expected %s to have length %d, got %d", fieldName, len(expected), len(actual))
 			return
@@ -173,10 +163,10 @@ func TestBuildSchedule_PartialScheduleBlock(t *testing.T) {
 		}
 	}
 
-	checkScheduleField("mdays", []int{-1})
-	checkScheduleField("minutes", []int{-1})
-	checkScheduleField("months", []int{-1})
-	checkScheduleField("wdays", []int{-1})
+	checkScheduleField("mdays", result.MDays, []int{-1})
+	checkScheduleField("minutes", result.Minutes, []int{-1})
+	checkScheduleField("months", result.Months, []int{-1})
+	checkScheduleField("wdays", result.WDays, []int{-1})
 }
 
 func TestBuildSchedule_EmptyScheduleFields(t *testing.T) {
@@ -212,12 +202,7 @@ func TestBuildSchedule_EmptyScheduleFields(t *testing.T) {
 	}
 
 	// Check that all fields default to [-1]
-	checkScheduleField := func(fieldName string, expected []int) {
-		actual, ok := result[fieldName].([]int)
-		if !ok {
-			t.Errorf("Expected %s to be []int, got %T", fieldName, result[fieldName])
-			return
-		}
+	checkScheduleField := func(fieldName string, actual, expected []int) {
 		if len(actual) != len(expected) {
 			t.Errorf("Expected %s to have length %d, got %d", fieldName, len(expected), len(actual))
 			return
@@ -229,9 +214,98 @@ func TestBuildSchedule_EmptyScheduleFields(t *testing.T) {
 		}
 	}
 
-	checkScheduleField("hours", []int{-1})
-	checkScheduleField("mdays", []int{-1})
-	checkScheduleField("minutes", []int{-1})
-	checkScheduleField("months", []int{-1})
-	checkScheduleField("wdays", []int{-1})
+	checkScheduleField("hours", result.Hours, []int{-1})
+	checkScheduleField("mdays", result.MDays, []int{-1})
+	checkScheduleField("minutes", result.Minutes, []int{-1})
+	checkScheduleField("months", result.Months, []int{-1})
+	checkScheduleField("wdays", result.WDays, []int{-1})
+}
+
+func TestResourceJob_Importer(t *testing.T) {
+	resource := resourceJob()
+
+	if resource.Importer == nil || resource.Importer.StateContext == nil {
+		t.Fatal("Importer with StateContext should be defined")
+	}
+
+	testCases := []struct {
+		name       string
+		id         string
+		expectedID string
+		expectErr  bool
+	}{
+		{name: "numeric ID", id: "12345", expectedID: "12345"},
+		{name: "numeric ID with whitespace", id: " 42 ", expectedID: "42"},
+		{name: "non-numeric ID", id: "my-job", expectErr: true},
+		{name: "zero ID", id: "0", expectErr: true},
+		{name: "negative ID", id: "-7", expectErr: true},
+		{name: "empty ID", id: "", expectErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			d := resource.TestResourceData()
+			d.SetId(tc.id)
+
+			result, err := resource.Importer.StateContext(context.Background(), d, nil)
+			if tc.expectErr {
+				if err == nil {
+					t.Fatalf("Expected error for ID %q, got nil", tc.id)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if len(result) != 1 {
+				t.Fatalf("Expected 1 resource, got %d", len(result))
+			}
+			if result[0].Id() != tc.expectedID {
+				t.Errorf("Expected ID %q, got %q", tc.expectedID, result[0].Id())
+			}
+		})
+	}
+}
+
+func TestExpandJobCreate(t *testing.T) {
+	resource := resourceJob()
+	resourceData := schema.TestResourceDataRaw(t, resource.Schema, map[string]interface{}{
+		"title":          "Test Job",
+		"url":            "https://example.com",
+		"enabled":        true,
+		"request_method": 1,
+		"extended_data": []interface{}{
+			map[string]interface{}{
+				"headers": map[string]interface{}{"X-Foo": "Bar"},
+				"body":    "payload",
+			},
+		},
+	})
+
+	job, err := expandJobCreate(resourceData)
+	if err != nil {
+		t.Fatalf("Error expanding job: %v", err)
+	}
+
+	if job.Title != "Test Job" || job.URL != "https://example.com" || !job.Enabled {
+		t.Errorf("Unexpected job fields: %+v", job)
+	}
+	if job.RequestTimeout != -1 {
+		t.Errorf("Expected request timeout default of -1, got %d", job.RequestTimeout)
+	}
+	if job.RequestMethod != client.RequestMethodPost {
+		t.Errorf("Expected request method POST, got %s", job.RequestMethod)
+	}
+	if job.Schedule == nil || job.Schedule.Timezone != "UTC" {
+		t.Errorf("Expected default schedule, got %+v", job.Schedule)
+	}
+	if job.Auth != nil {
+		t.Errorf("Expected auth to be omitted when not configured, got %+v", job.Auth)
+	}
+	if job.Notification != nil {
+		t.Errorf("Expected notification to be omitted when not configured, got %+v", job.Notification)
+	}
+	if job.ExtendedData == nil || job.ExtendedData.Headers["X-Foo"] != "Bar" || job.ExtendedData.Body != "payload" {
+		t.Errorf("Expected extended data to be set, got %+v", job.ExtendedData)
+	}
 }
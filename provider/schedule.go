@@ -0,0 +1,166 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/plain-insure/terraform-provider-cronjoborg/client"
+)
+
+// expiresNever is the value of expires_at_human for schedules that do not expire.
+const expiresNever = "never"
+
+// validateTimezone checks that a schedule time zone is a known IANA time zone.
+func validateTimezone(v interface{}, k string) ([]string, []error) {
+	value, ok := v.(string)
+	if !ok {
+		return nil, []error{fmt.Errorf("expected %q to be a string", k)}
+	}
+	if value == "" {
+		return nil, []error{fmt.Errorf("%q must not be empty", k)}
+	}
+	if _, err := (client.JobSchedule{Timezone: value}).Location(); err != nil {
+		return nil, []error{fmt.Errorf("%q: %w", k, err)}
+	}
+	return nil, nil
+}
+
+// validateExpiresAt checks that expires_at is 0 or an existing date and time in the
+// format YYYYMMDDhhmmss.
+func validateExpiresAt(v interface{}, k string) ([]string, []error) {
+	value, ok := v.(int)
+	if !ok {
+		return nil, []error{fmt.Errorf("expected %q to be an integer", k)}
+	}
+	if value == 0 {
+		return nil, nil
+	}
+	if _, err := client.ParseExpiresAt(value, time.UTC); err != nil {
+		return nil, []error{fmt.Errorf("%q: %w", k, err)}
+	}
+	return nil, nil
+}
+
+// scheduleAttributeConfigured reports whether the configuration sets the given
+// attribute of the schedule block. Unlike d.Get, it does not fall back to the state
+// when the attribute is removed.
+func scheduleAttributeConfigured(d *schema.ResourceData, name string) bool {
+	value, diags := d.GetRawConfigAt(cty.GetAttrPath("schedule").IndexInt(0).GetAttr(name))
+	return !diags.HasError() && !value.IsNull()
+}
+
+// scheduleListConfig returns the configured value of a time list of the schedule
+// block, or false if the configuration is not available or not yet known. A list
+// that is not configured is returned as an empty list.
+func scheduleListConfig(d *schema.ResourceData, name string) ([]interface{}, bool) {
+	if d.GetRawConfig().IsNull() {
+		return nil, false
+	}
+	value, diags := d.GetRawConfigAt(cty.GetAttrPath("schedule").IndexInt(0).GetAttr(name))
+	if diags.HasError() || !value.IsWhollyKnown() {
+		return nil, false
+	}
+	if value.IsNull() {
+		return []interface{}{}, true
+	}
+	return scheduleListFromValue(value)
+}
+
+// scheduleListFromValue converts a known list of numbers from the raw configuration
+// into a schedule list. It returns false if an element is null.
+func scheduleListFromValue(value cty.Value) ([]interface{}, bool) {
+	list := make([]interface{}, 0, value.LengthInt())
+	for _, element := range value.AsValueSlice() {
+		if element.IsNull() {
+			return nil, false
+		}
+		number, _ := element.AsBigFloat().Int64()
+		list = append(list, int(number))
+	}
+	return list, true
+}
+
+// scheduleListsEquivalent reports whether two schedule lists contain the same values,
+// ignoring order and duplicates and treating an empty list like [-1].
+func scheduleListsEquivalent(oldList, newList []interface{}) bool {
+	oldValues, oldOk := scheduleListInts(oldList)
+	newValues, newOk := scheduleListInts(newList)
+	if !oldOk || !newOk {
+		return false
+	}
+	return slices.Equal(client.NormalizeScheduleList(oldValues), client.NormalizeScheduleList(newValues))
+}
+
+// scheduleListInts converts a schedule list into integers.
+func scheduleListInts(list []interface{}) ([]int, bool) {
+	values := make([]int, len(list))
+	for i, v := range list {
+		value, ok := v.(int)
+		if !ok {
+			return nil, false
+		}
+		values[i] = value
+	}
+	return values, true
+}
+
+// suppressExpiresAtFromRFC3339 hides expires_at diffs if it is derived from expires_at_rfc3339.
+func suppressExpiresAtFromRFC3339(k, oldStr, newStr string, d *schema.ResourceData) bool {
+	return scheduleAttributeConfigured(d, "expires_at_rfc3339")
+}
+
+// suppressEquivalentRFC3339 treats RFC3339 times that denote the same instant as equal,
+// e.g. "2025-12-31T23:00:00Z" and "2026-01-01T00:00:00+01:00".
+func suppressEquivalentRFC3339(k, oldStr, newStr string, d *schema.ResourceData) bool {
+	oldTime, oldErr := time.Parse(time.RFC3339, oldStr)
+	newTime, newErr := time.Parse(time.RFC3339, newStr)
+	return oldErr == nil && newErr == nil && oldTime.Equal(newTime)
+}
+
+// expandExpiresAtRFC3339 converts expires_at_rfc3339 into an expiresAt value in the
+// schedule's time zone.
+func expandExpiresAtRFC3339(value string, schedule client.JobSchedule) (int, error) {
+	loc, err := schedule.Location()
+	if err != nil {
+		return 0, err
+	}
+	expiresAt, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return 0, fmt.Errorf("schedule.expires_at_rfc3339 must be an RFC3339 time: %w", err)
+	}
+	return client.FormatExpiresAt(expiresAt.In(loc)), nil
+}
+
+// flattenExpiresAtRFC3339 returns expires_at_rfc3339 for the state: the configured
+// value if it still denotes the job's expiry, or the job's expiry otherwise.
+func flattenExpiresAtRFC3339(configured string, schedule client.JobSchedule) string {
+	if configured == "" {
+		return ""
+	}
+	if expiresAt, err := expandExpiresAtRFC3339(configured, schedule); err == nil && expiresAt == schedule.ExpiresAt {
+		return configured
+	}
+	t, err := schedule.ExpiresAtTime()
+	if err != nil || t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
+
+// humanExpiry describes when a schedule expires, e.g. "2025-12-31 23:59:00 CET".
+func humanExpiry(schedule client.JobSchedule) string {
+	t, err := schedule.ExpiresAtTime()
+	if err != nil {
+		return fmt.Sprintf("invalid (%d)", schedule.ExpiresAt)
+	}
+	if t.IsZero() {
+		return expiresNever
+	}
+	return t.Format("2006-01-02 15:04:05 MST")
+}
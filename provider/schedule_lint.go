@@ -0,0 +1,233 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/plain-insure/terraform-provider-cronjoborg/client"
+)
+
+// scheduleLintDSTWindow is how far ahead schedules are checked for run times that
+// daylight saving time changes skip or repeat.
+const scheduleLintDSTWindow = 366 * 24 * time.Hour
+
+// scheduleLintList is a time list of a schedule with the name of its unit.
+type scheduleLintList struct {
+	field  string
+	unit   string
+	values []int
+}
+
+// lintSchedule checks a schedule for run times that can never happen (errors) and
+// for lists and run times that are likely not intended (warnings).
+func lintSchedule(schedule client.JobSchedule, now time.Time) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	for _, list := range []scheduleLintList{
+		{"hours", "hour", schedule.Hours},
+		{"mdays", "day of month", schedule.MDays},
+		{"minutes", "minute", schedule.Minutes},
+		{"months", "month", schedule.Months},
+		{"wdays", "day of week", schedule.WDays},
+	} {
+		diags = append(diags, lintScheduleList(list)...)
+	}
+	if diags.HasError() {
+		return diags
+	}
+
+	// Look for the first run regardless of the expiry, which is checked below.
+	unbounded := schedule
+	unbounded.ExpiresAt = 0
+	runs, err := unbounded.Next(now, 1)
+	if err != nil {
+		// Invalid time zones and expiry dates are reported by attribute validation.
+		return diags
+	}
+	if len(runs) == 0 {
+		return append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Schedule never runs",
+			Detail: fmt.Sprintf("The schedule %q matches no date in the next 30 years. "+
+				"Check that the days of month exist in the selected months, e.g. there is no 31st in February, April, June, September and November.",
+				schedule.CronExpression()),
+			AttributePath: cty.GetAttrPath("schedule").IndexInt(0),
+		})
+	}
+
+	expiresAt, err := schedule.ExpiresAtTime()
+	if err == nil && !expiresAt.IsZero() && runs[0].After(expiresAt) {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "Job expires before its first run",
+			Detail: fmt.Sprintf("The schedule expires at %s, but its next run time is %s, so the job will not run.",
+				expiresAt.Format(time.RFC3339), runs[0].Format(time.RFC3339)),
+			AttributePath: cty.GetAttrPath("schedule").IndexInt(0),
+		})
+	}
+
+	// Jobs that run every hour skip or repeat an hour anyway when the clocks change.
+	if !scheduleMatchesEveryValue(schedule.Hours) {
+		diags = append(diags, lintScheduleDaylightSaving(schedule, now)...)
+	}
+
+	return diags
+}
+
+// lintScheduleList checks a single time list for misuse of the -1 sentinel and
+// duplicates.
+func lintScheduleList(list scheduleLintList) diag.Diagnostics {
+	path := cty.GetAttrPath("schedule").IndexInt(0).GetAttr(list.field)
+
+	if len(list.values) > 1 && slices.Contains(list.values, -1) {
+		return diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  "Invalid schedule list",
+			Detail: fmt.Sprintf("schedule.%s %v combines -1, which means every %s, with specific values. "+
+				"Use either [-1] or a list of specific values.", list.field, list.values, list.unit),
+			AttributePath: path,
+		}}
+	}
+
+	var diags diag.Diagnostics
+	seen := map[int]bool{}
+	for _, value := range list.values {
+		if seen[value] {
+			diags = append(diags, diag.Diagnostic{
+				Severity:      diag.Warning,
+				Summary:       "Duplicate schedule value",
+				Detail:        fmt.Sprintf("schedule.%s %v lists %d more than once.", list.field, list.values, value),
+				AttributePath: path,
+			})
+			break
+		}
+		seen[value] = true
+	}
+	return diags
+}
+
+// lintScheduleDaylightSaving warns about run times in the next year that daylight
+// saving time changes of the schedule's time zone skip or repeat.
+func lintScheduleDaylightSaving(schedule client.JobSchedule, now time.Time) diag.Diagnostics {
+	dstRuns, err := schedule.DaylightSavingRuns(now, now.Add(scheduleLintDSTWindow))
+	if err != nil {
+		return nil
+	}
+
+	var skipped, repeated []time.Time
+	for _, run := range dstRuns {
+		if run.Skipped {
+			skipped = append(skipped, run.Wall)
+		} else {
+			repeated = append(repeated, run.Wall)
+		}
+	}
+
+	var diags diag.Diagnostics
+	if len(skipped) > 0 {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "Run skipped by daylight saving time",
+			Detail: fmt.Sprintf("The clocks in %s move forward past %d run time(s) in the next year, e.g. %s, so the job does not run at these times.",
+				schedule.Timezone, len(skipped), skipped[0].Format("2006-01-02 15:04")),
+			AttributePath: cty.GetAttrPath("schedule").IndexInt(0).GetAttr("hours"),
+		})
+	}
+	if len(repeated) > 0 {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "Run repeated by daylight saving time",
+			Detail: fmt.Sprintf("The clocks in %s go back over %d run time(s) in the next year, e.g. %s, so these local times occur twice and the job may run twice.",
+				schedule.Timezone, len(repeated), repeated[0].Format("2006-01-02 15:04")),
+			AttributePath: cty.GetAttrPath("schedule").IndexInt(0).GetAttr("hours"),
+		})
+	}
+	return diags
+}
+
+// scheduleMatchesEveryValue reports whether a time list is empty or the sentinel [-1].
+func scheduleMatchesEveryValue(values []int) bool {
+	return len(values) == 0 || slices.Equal(values, []int{-1})
+}
+
+// customizeDiffScheduleLint fails the plan if the configured schedule can never run.
+func customizeDiffScheduleLint(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	schedule, ok := scheduleFromRawConfig(d.GetRawConfig())
+	if !ok {
+		return nil
+	}
+
+	var errs []error
+	for _, diagnostic := range lintSchedule(schedule, time.Now()) {
+		if diagnostic.Severity == diag.Error {
+			errs = append(errs, fmt.Errorf("%s: %s", diagnostic.Summary, diagnostic.Detail))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// validateScheduleLintWarnings reports the schedule lint warnings during validation,
+// as CustomizeDiff can only return errors.
+func validateScheduleLintWarnings(ctx context.Context, req schema.ValidateResourceConfigFuncRequest, resp *schema.ValidateResourceConfigFuncResponse) {
+	schedule, ok := scheduleFromRawConfig(req.RawConfig)
+	if !ok {
+		return
+	}
+
+	for _, diagnostic := range lintSchedule(schedule, time.Now()) {
+		if diagnostic.Severity == diag.Warning {
+			resp.Diagnostics = append(resp.Diagnostics, diagnostic)
+		}
+	}
+}
+
+// scheduleFromRawConfig expands the schedule block of a raw resource configuration.
+// It returns false if no schedule is configured or if it is not yet known.
+func scheduleFromRawConfig(config cty.Value) (client.JobSchedule, bool) {
+	if config.IsNull() || !config.IsKnown() || !config.Type().IsObjectType() || !config.Type().HasAttribute("schedule") {
+		return client.JobSchedule{}, false
+	}
+	block := config.GetAttr("schedule")
+	if block.IsNull() || !block.IsWhollyKnown() || block.LengthInt() == 0 {
+		return client.JobSchedule{}, false
+	}
+
+	// Optional attributes are null in the raw configuration, so apply the defaults.
+	scheduleMap := map[string]interface{}{
+		"timezone":   "UTC",
+		"expires_at": 0,
+	}
+	for name, value := range block.Index(cty.NumberIntVal(0)).AsValueMap() {
+		if value.IsNull() {
+			continue
+		}
+		switch {
+		case value.Type() == cty.String:
+			scheduleMap[name] = value.AsString()
+		case value.Type() == cty.Number:
+			number, _ := value.AsBigFloat().Int64()
+			scheduleMap[name] = int(number)
+		case value.Type().IsListType():
+			list, ok := scheduleListFromValue(value)
+			if !ok {
+				return client.JobSchedule{}, false
+			}
+			scheduleMap[name] = list
+		}
+	}
+
+	schedule, err := expandSchedule(scheduleMap)
+	if err != nil {
+		return client.JobSchedule{}, false
+	}
+	return schedule, true
+}
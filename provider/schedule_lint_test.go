@@ -0,0 +1,115 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/plain-insure/terraform-provider-cronjoborg/client"
+)
+
+func TestLintSchedule(t *testing.T) {
+	now := time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+	every := []int{-1}
+
+	tests := []struct {
+		name     string
+		schedule client.JobSchedule
+		errors   []string
+		warnings []string
+	}{
+		{
+			name:     "valid",
+			schedule: client.JobSchedule{Timezone: "Europe/Berlin", Hours: []int{9, 17}, MDays: every, Minutes: []int{0, 30}, Months: every, WDays: []int{1, 2, 3, 4, 5}},
+		},
+		{
+			name:     "every minute",
+			schedule: client.JobSchedule{Timezone: "Europe/Berlin", Hours: every, MDays: every, Minutes: every, Months: every, WDays: every},
+		},
+		{
+			name:     "never runs",
+			schedule: client.JobSchedule{Timezone: "UTC", Hours: []int{0}, MDays: []int{31}, Minutes: []int{0}, Months: []int{2, 4, 6}, WDays: every},
+			errors:   []string{"Schedule never runs"},
+		},
+		{
+			name:     "sentinel mixed with values",
+			schedule: client.JobSchedule{Timezone: "UTC", Hours: []int{-1, 5}, MDays: every, Minutes: []int{0}, Months: every, WDays: every},
+			errors:   []string{"Invalid schedule list"},
+		},
+		{
+			name:     "duplicate",
+			schedule: client.JobSchedule{Timezone: "UTC", Hours: []int{12, 6, 12}, MDays: every, Minutes: []int{0}, Months: every, WDays: every},
+			warnings: []string{"Duplicate schedule value"},
+		},
+		{
+			name:     "daylight saving time",
+			schedule: client.JobSchedule{Timezone: "Europe/Berlin", Hours: []int{2}, MDays: every, Minutes: []int{30}, Months: every, WDays: every},
+			warnings: []string{"Run skipped by daylight saving time", "Run repeated by daylight saving time"},
+		},
+		{
+			name:     "expires before first run",
+			schedule: client.JobSchedule{Timezone: "UTC", ExpiresAt: 20250301000000, Hours: []int{0}, MDays: []int{1}, Minutes: []int{0}, Months: []int{6}, WDays: every},
+			warnings: []string{"Job expires before its first run"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var errors, warnings []string
+			for _, d := range lintSchedule(tt.schedule, now) {
+				if d.Severity == diag.Error {
+					errors = append(errors, d.Summary)
+				} else {
+					warnings = append(warnings, d.Summary)
+				}
+			}
+			if !slices.Equal(errors, tt.errors) {
+				t.Errorf("Expected errors %v, got %v", tt.errors, errors)
+			}
+			if !slices.Equal(warnings, tt.warnings) {
+				t.Errorf("Expected warnings %v, got %v", tt.warnings, warnings)
+			}
+		})
+	}
+}
+
+func TestValidateScheduleLintWarnings(t *testing.T) {
+	config := func(schedule cty.Value) cty.Value {
+		return cty.ObjectVal(map[string]cty.Value{
+			"title":    cty.StringVal("Backup"),
+			"schedule": cty.ListVal([]cty.Value{schedule}),
+		})
+	}
+	hours := func(values ...cty.Value) cty.Value {
+		return cty.ObjectVal(map[string]cty.Value{
+			"timezone":        cty.NullVal(cty.String),
+			"expires_at":      cty.NullVal(cty.Number),
+			"cron_expression": cty.NullVal(cty.String),
+			"hours":           cty.ListVal(values),
+		})
+	}
+
+	resp := &schema.ValidateResourceConfigFuncResponse{}
+	validateScheduleLintWarnings(context.Background(), schema.ValidateResourceConfigFuncRequest{
+		RawConfig: config(hours(cty.NumberIntVal(6), cty.NumberIntVal(6))),
+	}, resp)
+	if len(resp.Diagnostics) != 1 || resp.Diagnostics[0].Summary != "Duplicate schedule value" {
+		t.Errorf("Expected a duplicate value warning, got %v", resp.Diagnostics)
+	}
+
+	// Unknown values are checked once they are known.
+	resp = &schema.ValidateResourceConfigFuncResponse{}
+	validateScheduleLintWarnings(context.Background(), schema.ValidateResourceConfigFuncRequest{
+		RawConfig: config(hours(cty.NumberIntVal(6), cty.UnknownVal(cty.Number))),
+	}, resp)
+	if len(resp.Diagnostics) != 0 {
+		t.Errorf("Expected no diagnostics for unknown values, got %v", resp.Diagnostics)
+	}
+}
@@ -0,0 +1,85 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/plain-insure/terraform-provider-cronjoborg/client"
+)
+
+func TestValidateTimezone(t *testing.T) {
+	for _, valid := range []string{"UTC", "Europe/Berlin", "America/Argentina/Buenos_Aires", "Etc/GMT+5"} {
+		if _, errs := validateTimezone(valid, "timezone"); len(errs) != 0 {
+			t.Errorf("Expected %q to be valid, got %v", valid, errs)
+		}
+	}
+	for _, invalid := range []string{"", "Local", "Europe/Berln", "CEST", "+01:00"} {
+		if _, errs := validateTimezone(invalid, "timezone"); len(errs) == 0 {
+			t.Errorf("Expected %q to be rejected", invalid)
+		}
+	}
+}
+
+func TestValidateExpiresAt(t *testing.T) {
+	for _, valid := range []int{0, 20251231235959, 20280229120000} {
+		if _, errs := validateExpiresAt(valid, "expires_at"); len(errs) != 0 {
+			t.Errorf("Expected %d to be valid, got %v", valid, errs)
+		}
+	}
+	for _, invalid := range []int{20251399000000, 20250229000000, 20250431000000, 20251231246000, 1} {
+		if _, errs := validateExpiresAt(invalid, "expires_at"); len(errs) == 0 {
+			t.Errorf("Expected %d to be rejected", invalid)
+		}
+	}
+}
+
+func TestExpandExpiresAtRFC3339(t *testing.T) {
+	schedule := client.JobSchedule{Timezone: "Europe/Berlin"}
+
+	expiresAt, err := expandExpiresAtRFC3339("2030-06-30T22:30:00Z", schedule)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if expiresAt != 20300701003000 {
+		t.Errorf("Expected the expiry in the schedule's time zone, got %d", expiresAt)
+	}
+}
+
+func TestFlattenExpiresAtRFC3339(t *testing.T) {
+	schedule := client.JobSchedule{Timezone: "Europe/Berlin", ExpiresAt: 20300701003000}
+
+	tests := []struct {
+		name       string
+		configured string
+		want       string
+	}{
+		{"not configured", "", ""},
+		{"unchanged", "2030-06-30T22:30:00Z", "2030-06-30T22:30:00Z"},
+		{"changed outside of Terraform", "2030-01-01T00:00:00Z", "2030-07-01T00:30:00+02:00"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := flattenExpiresAtRFC3339(tt.configured, schedule); got != tt.want {
+				t.Errorf("flattenExpiresAtRFC3339() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHumanExpiry(t *testing.T) {
+	tests := []struct {
+		schedule client.JobSchedule
+		want     string
+	}{
+		{client.JobSchedule{Timezone: "UTC"}, "never"},
+		{client.JobSchedule{Timezone: "Europe/Berlin", ExpiresAt: 20301231235900}, "2030-12-31 23:59:00 CET"},
+		{client.JobSchedule{Timezone: "Europe/Berlin", ExpiresAt: 20300701000000}, "2030-07-01 00:00:00 CEST"},
+	}
+	for _, tt := range tests {
+		if got := humanExpiry(tt.schedule); got != tt.want {
+			t.Errorf("humanExpiry(%+v) = %q, want %q", tt.schedule, got, tt.want)
+		}
+	}
+}